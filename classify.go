@@ -0,0 +1,68 @@
+package goexer
+
+import (
+	"context"
+	"errors"
+)
+
+// Classifier - inspect original (non goexer) error during Wrap(). Could enrich err, e.g. set container fields.
+// Return kind (name) of error or empty string if error was not recognized.
+type Classifier func(orig error, err *Error) string
+
+var classifiers = []Classifier{classifyContext, classifyRetryable, classifySQL, classifyOS, classifyEmbedded}
+
+// RegisterClassifier - add classifier for wrapped errors. Classifiers registered later have higher priority.
+// Should be called before errors are created (e.g. in init()).
+func RegisterClassifier(c Classifier) {
+	classifiers = append(classifiers, c)
+}
+
+// classify - run all classifiers for orig. Return kind from classifier with the highest priority.
+func classify(orig error, err *Error) string {
+	kind := ""
+
+	for i := len(classifiers) - 1; i >= 0; i-- {
+		if k := classifiers[i](orig, err); k != "" && kind == "" {
+			kind = k
+		}
+	}
+
+	return kind
+}
+
+// Recognize context errors.
+func classifyContext(orig error, _ *Error) string {
+	switch {
+	case errors.Is(orig, context.Canceled):
+		return CanceledErrorName
+	case errors.Is(orig, context.DeadlineExceeded):
+		return DeadlineExceededErrorName
+	}
+
+	return ""
+}
+
+// Recognize goexer errors wrapped by other errors, e.g. by *url.Error of http.Client.
+// Kind, container fields and retry options of embedded error are inherited.
+func classifyEmbedded(orig error, err *Error) string {
+	var e *Error
+	if !errors.As(orig, &e) {
+		return ""
+	}
+
+	if e.Retryable {
+		err.Retryable = true
+	}
+	if err.RetryAfter == 0 {
+		err.RetryAfter = e.RetryAfter
+	}
+	if e.Container != nil {
+		err.addFields(e.Container.Marshaled())
+	}
+
+	if e.Name == BaseErrorName {
+		return ""
+	}
+
+	return e.Name
+}
@@ -0,0 +1,114 @@
+package goexer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+)
+
+const colorReset = "\x1b[0m"
+
+// ColorScheme - ANSI escape sequences for styles used by "paint" function in layout templates.
+var ColorScheme = map[string]string{
+	"name":     "\x1b[1;31m", // Error name. Bold red.
+	"function": "\x1b[1m",    // Function. Bold.
+	"message":  "\x1b[1;37m", // Error message. Bold white.
+	"location": "\x1b[36m",   // file:line. Cyan.
+	"field":    "\x1b[33m",   // Container field name. Yellow.
+	"hint":     "\x1b[32m",   // Hint text. Green.
+	"url":      "\x1b[4m",    // Hint documentation link. Underline.
+}
+
+// Paint value with style from ColorScheme. Used as "paint" function in colored layout templates.
+func colorPaint(style string, v any) string {
+	s := fmt.Sprintf("%v", v)
+
+	code, ok := ColorScheme[style]
+	if !ok || s == "" {
+		return s
+	}
+
+	return code + s + colorReset
+}
+
+// Return value without colors. Used as "paint" function in plain layout templates.
+func plainPaint(_ string, v any) string {
+	return fmt.Sprintf("%v", v)
+}
+
+// Return template with colored "paint" function.
+func colorTemplate(t *template.Template) *template.Template {
+	if t == nil {
+		return nil
+	}
+
+	ct, err := t.Clone()
+	if err != nil {
+		return t
+	}
+
+	return ct.Funcs(template.FuncMap{"paint": colorPaint})
+}
+
+// Colored pretty string for error in one line style. See ColorScheme.
+func (e *Error) ColorOneLinePrettyError() string {
+	return e.render(func(l *Layout) *template.Template { return colorTemplate(l.OneLine) })
+}
+
+// Colored pretty string for error in multi line style. See ColorScheme.
+func (e *Error) ColorMultiLinePrettyError() string {
+	return e.colorMultiLine() + hintsString(e.ownHints(), colorPaint)
+}
+
+// Render colored multi line template of error without hints.
+func (e *Error) colorMultiLine() string {
+	return e.render(func(l *Layout) *template.Template { return colorTemplate(l.MultiLine) })
+}
+
+// Return stack as colored pretty string. Also available as %+#v format if ColorEnabled(os.Stdout).
+func (e *Error) ColorStackString() string {
+	s := ""
+
+	for _, err := range e.Stack() {
+		s += err.colorMultiLine()
+	}
+
+	return s + hintsString(e.AllHints(), colorPaint)
+}
+
+// ColorEnabled - check if colors should be used for w. Returns true if w is a terminal and NO_COLOR is not set.
+func ColorEnabled(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// FprintStack - write stack of err to w. Stack is colored if ColorEnabled(w).
+// Non goexer errors are written as err.Error(). Nothing is written for nil err.
+func FprintStack(w io.Writer, err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+
+	if !IsGoexerError(err) {
+		return fmt.Fprintln(w, err.Error())
+	}
+
+	if !ColorEnabled(w) {
+		return fmt.Fprint(w, ToError(err).StackString())
+	}
+
+	//nolint:forcetypeassert
+	return fmt.Fprint(colorable.NewColorable(w.(*os.File)), ToError(err).ColorStackString())
+}
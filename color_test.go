@@ -0,0 +1,87 @@
+package goexer_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+var ansiRe = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColorStackString(t *testing.T) {
+	t.Parallel()
+
+	tr := true
+	//nolint:goerr113
+	err := goexer.Wrap(errors.New("original"), "current", goexer.ErrorOpts{
+		Container:          goexer.NewContainer().Set("id", 1),
+		ShowContainerItems: []string{"id"},
+		ShowContainerSize:  &tr,
+	})
+
+	colored := err.ColorStackString()
+	if !strings.Contains(colored, goexer.ColorScheme["message"]+"current\x1b[0m") {
+		t.Errorf("Message should be colored, got %q", colored)
+	}
+	if !strings.Contains(colored, goexer.ColorScheme["field"]+"id\x1b[0m") {
+		t.Errorf("Field should be colored, got %q", colored)
+	}
+	if ansiRe.ReplaceAllString(colored, "") != err.StackString() {
+		t.Errorf("Colored stack without colors should be equal to StackString():\n%q\n%q", colored, err.StackString())
+	}
+	want := err.StackString()
+	if goexer.ColorEnabled(os.Stdout) {
+		want = colored
+	}
+	if fmt.Sprintf("%+#v", err) != want {
+		t.Errorf("%%+#v should return colored stack only for terminal stdout, got %q", fmt.Sprintf("%+#v", err))
+	}
+
+	one := err.ColorOneLinePrettyError()
+	if !strings.Contains(one, goexer.ColorScheme["name"]+"BaseError\x1b[0m") {
+		t.Errorf("Name should be colored, got %q", one)
+	}
+	if ansiRe.ReplaceAllString(one, "") != err.Error() {
+		t.Errorf("Colored error without colors should be equal to Error(): %q", one)
+	}
+}
+
+func TestFprintStack(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	err := goexer.New("test")
+
+	if _, e := goexer.FprintStack(buf, err); e != nil || buf.String() != err.StackString() {
+		t.Errorf("Want plain stack for non terminal writer, got %q (%v)", buf.String(), e)
+	}
+
+	buf.Reset()
+	//nolint:goerr113
+	if _, e := goexer.FprintStack(buf, errors.New("plain")); e != nil || buf.String() != "plain\n" {
+		t.Errorf("Want 'plain', got %q (%v)", buf.String(), e)
+	}
+
+	buf.Reset()
+	if n, e := goexer.FprintStack(buf, nil); n != 0 || e != nil || buf.Len() > 0 {
+		t.Errorf("Want nothing for nil error, got %q (%v)", buf.String(), e)
+	}
+}
+
+//nolint:paralleltest
+func TestColorEnabledNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if goexer.ColorEnabled(os.Stdout) {
+		t.Error("Colors should be disabled with NO_COLOR")
+	}
+	if goexer.ColorEnabled(&bytes.Buffer{}) {
+		t.Error("Colors should be disabled for non file writers")
+	}
+}
@@ -135,6 +135,20 @@ func (c *Container) GetRawE(key string) (any, bool) {
 	return item.GetRaw(), true
 }
 
+// Return Item with ok status. Used for encoding with exact type of value.
+func (c *Container) GetItem(key string) (Item, bool) {
+	item, ok := c.items[key]
+
+	return item, ok
+}
+
+// Set Item as is. Used for decoding, Item.Type could differ from type of Item.Value.
+func (c *Container) SetItem(item Item) *Container {
+	c.items[item.Name] = item
+
+	return c
+}
+
 // Set value for item.
 func (c *Container) Set(key string, value interface{}) *Container {
 	item := Item{
@@ -157,6 +171,16 @@ func NewContainer() *Container {
 	return &cc
 }
 
+// Return copy of Container. Values are not copied deeply.
+func (c *Container) Clone() *Container {
+	cc := NewContainer()
+	for k, v := range c.items {
+		cc.items[k] = v
+	}
+
+	return cc
+}
+
 // Return count of fields (items) inside Container.
 func (c *Container) Size() int {
 	return len(c.items)
@@ -127,3 +127,23 @@ func TestContainerGetRawE(t *testing.T) {
 		t.Errorf("GetRawE not exists want 'nil', got '%v'", cc.GetRaw("not-exists"))
 	}
 }
+
+func TestContainerItem(t *testing.T) {
+	t.Parallel()
+
+	c := goexer.NewContainer().Set("id", int32(10))
+
+	item, ok := c.GetItem("id")
+	if !ok || item.Type != "int32" || item.Value != int32(10) {
+		t.Errorf("Want int32 item, got %v", item.String())
+	}
+
+	if _, ok := c.GetItem("none"); ok {
+		t.Errorf("Want no item")
+	}
+
+	c.SetItem(goexer.Item{Name: "count", Type: "int16", Value: int64(5)})
+	if c.Get("count") != int16(5) {
+		t.Errorf("Want int16(5), got %#v", c.Get("count"))
+	}
+}
@@ -0,0 +1,99 @@
+package goexer
+
+import (
+	"context"
+	"fmt"
+)
+
+type ctxFieldsKey struct{}
+
+// WithFields - return copy of ctx with fields that will be added to Container of errors created by NewCtx(), WrapCtx().
+// keysAndValues are pairs of key and value, e.g. WithFields(ctx, "request_id", id, "tenant", tenant).
+// Fields from parent context are kept. Pair without value and nil values are ignored.
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	parent := FieldsFromContext(ctx)
+	fields := make(map[string]any, len(parent)+len(keysAndValues)/2)
+
+	for k, v := range parent {
+		fields[k] = v
+	}
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if keysAndValues[i+1] == nil {
+			continue
+		}
+		fields[fmt.Sprintf("%v", keysAndValues[i])] = keysAndValues[i+1]
+	}
+
+	return context.WithValue(ctx, ctxFieldsKey{}, fields)
+}
+
+// Return fields stored in context by WithFields(). Returns nil if there are no fields.
+func FieldsFromContext(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(ctxFieldsKey{}).(map[string]any)
+
+	return fields
+}
+
+// addFields - add fields to error's container. nil values are skipped.
+// Container is cloned, because it could be shared between errors (see ErrorOpts.Container).
+func (e *Error) addFields(fields map[string]any) {
+	if len(fields) == 0 {
+		return
+	}
+
+	e.Container = e.Container.Clone()
+	for k, v := range fields {
+		if v != nil {
+			e.Set(k, v)
+		}
+	}
+}
+
+// NewCtx - create new Error with fields from context. Context is used for pprof labels as well.
+func NewCtx(ctx context.Context, msg string, args ...ErrorOpts) *Error {
+	if len(args) > 1 {
+		fatal(New("Only one or zero ErrorOpts could be passed to NewCtx()"), "Only one or zero ErrorOpts could be passed to NewCtx()")
+	}
+
+	opts := DefaultErrorOpts
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	if opts.Context == nil {
+		opts.Context = ctx
+	}
+
+	err := newError(2+opts.Depth, msg, opts)
+	err.addFields(FieldsFromContext(ctx))
+
+	return err
+}
+
+// WrapCtx - wrap old error to the new one with fields from context. Context is used for pprof labels as well.
+func WrapCtx(ctx context.Context, prev error, msg string, args ...ErrorOpts) *Error {
+	if len(args) > 1 {
+		fatal(New("Only one or zero ErrorOpts could be passed to WrapCtx()"), "Only one or zero ErrorOpts could be passed to WrapCtx()")
+	}
+
+	opts := DefaultErrorOpts
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	if opts.Context == nil {
+		opts.Context = ctx
+	}
+
+	err := wrap(3, prev, msg, opts)
+	err.addFields(FieldsFromContext(ctx))
+
+	return err
+}
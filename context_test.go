@@ -0,0 +1,104 @@
+package goexer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestNewCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := goexer.WithFields(context.Background(), "request_id", "req-1", "tenant", "acme")
+	ctx = goexer.WithFields(ctx, "user", 42, "odd")
+
+	c := goexer.NewContainer().Set("shared", true)
+	_, file, line, _ := runtime.Caller(0)
+	err := goexer.NewCtx(ctx, "test", goexer.ErrorOpts{Container: c})
+
+	if err.File != file || err.Line != uint(line+1) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+1, err.File, err.Line)
+	}
+
+	for k, v := range map[string]any{"request_id": "req-1", "tenant": "acme", "user": 42, "shared": true} {
+		if err.Get(k) != v {
+			t.Errorf("Want %s=%v, got '%v'", k, v, err.Get(k))
+		}
+	}
+	if err.Container.Size() != 4 {
+		t.Errorf("Want container size 4, got %d", err.Container.Size())
+	}
+
+	// Shared container should not be changed.
+	if c.Size() != 1 {
+		t.Errorf("Shared container was changed, size: %d", c.Size())
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := goexer.WithFields(context.Background(), "request_id", "req-2")
+	//nolint:goerr113
+	err := goexer.WrapCtx(ctx, errors.New("original"), "current")
+
+	if err.Get("request_id") != "req-2" {
+		t.Errorf("Want request_id=req-2, got '%v'", err.Get("request_id"))
+	}
+	if err.Previous == nil || err.Previous.Original == nil || err.Previous.Original.Error() != "original" {
+		t.Errorf("Incorrect previous error: '%v'", err.Previous)
+	}
+	if err.Name != goexer.BaseErrorName {
+		t.Errorf("Want name %s, got %s", goexer.BaseErrorName, err.Name)
+	}
+}
+
+func TestWrapContextErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := goexer.Wrap(ctx.Err(), "canceled")
+	if err.Name != goexer.CanceledErrorName || err.Previous.Name != goexer.CanceledErrorName {
+		t.Errorf("Want name %s, got %s", goexer.CanceledErrorName, err.Name)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err = goexer.Wrap(fmt.Errorf("query: %w", ctx.Err()), "timeout")
+	if err.Name != goexer.DeadlineExceededErrorName {
+		t.Errorf("Want name %s, got %s", goexer.DeadlineExceededErrorName, err.Name)
+	}
+	if !errors.Is(goexer.Wrap(err, "outer"), goexer.New("", goexer.ErrorOpts{Name: goexer.DeadlineExceededErrorName})) {
+		t.Errorf("Want kind %s for outer error", goexer.DeadlineExceededErrorName)
+	}
+
+	// Explicit name has priority.
+	err = goexer.Wrap(context.Canceled, "named", goexer.ErrorOpts{Name: "Custom"})
+	if err.Name != "Custom" {
+		t.Errorf("Want name Custom, got %s", err.Name)
+	}
+}
+
+func TestWrapfLocation(t *testing.T) {
+	t.Parallel()
+
+	_, file, line, _ := runtime.Caller(0)
+	//nolint:goerr113
+	err := goexer.Wrapf(errors.New("original"), "wrapped %d", 1)
+
+	if err.File != file || err.Line != uint(line+2) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+2, err.File, err.Line)
+	}
+	if err.Message != "wrapped 1" {
+		t.Errorf("Want message 'wrapped 1', got '%s'", err.Message)
+	}
+}
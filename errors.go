@@ -1,10 +1,15 @@
 package goexer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"runtime"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -20,19 +25,42 @@ type Error struct {
 	Container            *Container
 	ShowContainerItems   []string
 	ShowContainerSize    bool
-	ShowContainerAsZKeys bool // Show container items as keys in zerolog, instead of as message.
-	AddTraceToError      bool // Add trace messages to error and fatal messages with error level ERROR.
+	ShowContainerAsZKeys bool              // Show container items as keys in zerolog, instead of as message.
+	AddTraceToError      bool              // Add trace messages to error and fatal messages with error level ERROR.
+	GoroutineID          uint64            // Id of goroutine where error was created. 0 if was not captured.
+	GoroutineLabels      map[string]string // pprof labels of goroutine where error was created.
+	Retryable            bool              // Error is transient, operation could be retried.
+	NotRetryable         bool              // Error is explicitly not retryable (ErrorOpts.Retryable is false). See IsRetryable().
+	RetryAfter           time.Duration     // Suggested delay before retry. 0 if unknown.
+	Severity             Severity          // Severity of error. Used by Log().
+	Layout               *Layout           // Templates for text representation. nil - layout of kind or default layout.
+	Joined               []*Error          // Errors joined into this error by Join().
+	Frames               []Frame           // Call frames captured with ErrorOpts.CaptureFrames. See FilteredFrames().
+	MessageTemplate      string            // Raw message template for errors created by NewT(), WrapT().
+	PublicMessage        string            // Message which could be shown to clients. See Public().
+	PublicDetail         string            // Detail which could be shown to clients.
+	Hints                []Hint            // Remediation hints for users. See AllHints().
 }
 
 // Additional options for New(), Wrap(), ...
 type ErrorOpts struct {
-	Name                 string     // Name (kind) of error. Do not use long strings for better formatting.
-	Depth                int        // Depth of stack trace. Increase if need fetch data from previous frame.
-	Container            *Container // Use existing container with prefilled data. Use nil for personal container for each error.
-	ShowContainerSize    *bool      // Show container size in error message.
-	ShowContainerItems   []string   // Show container items in error message (key and value).
-	ShowContainerAsZKeys *bool      // Show container items as keys in zerolog, instead of as message.
-	AddTraceToError      *bool      // Add trace messages to error and fatal messages with error level ERROR.
+	Name                 string          // Name (kind) of error. Do not use long strings for better formatting.
+	Depth                int             // Depth of stack trace. Increase if need fetch data from previous frame.
+	Container            *Container      // Use existing container with prefilled data. Use nil for personal container for each error.
+	ShowContainerSize    *bool           // Show container size in error message.
+	ShowContainerItems   []string        // Show container items in error message (key and value).
+	ShowContainerAsZKeys *bool           // Show container items as keys in zerolog, instead of as message.
+	AddTraceToError      *bool           // Add trace messages to error and fatal messages with error level ERROR.
+	CaptureGoroutine     *bool           // Record goroutine id and pprof labels (from Context) where error was created.
+	Context              context.Context // Context for pprof labels lookup. Could be nil.
+	Retryable            *bool           // Error is transient, operation could be retried.
+	RetryAfter           time.Duration   // Suggested delay before retry.
+	Severity             Severity        // Severity of error. By default severity of kind (see RegisterKind()) or SeverityError.
+	Layout               *Layout         // Templates for text representation. nil - layout of kind or default layout.
+	CaptureFrames        int             // Capture up to CaptureFrames call frames. 0 - only location of error.
+	PublicMessage        string          // Message which could be shown to clients. See Public().
+	PublicDetail         string          // Detail which could be shown to clients.
+	Hints                []Hint          // Remediation hints for users. See AllHints().
 }
 
 func (e *Error) Error() string {
@@ -76,41 +104,19 @@ func (e *Error) Cause() error {
 	return e.Original
 }
 
-// Pretty string for error in one line style.
+// Pretty string for error in one line style. See Layout.
 func (e *Error) OneLinePrettyError() string {
-	file := strings.Split(e.File, "/")
-
-	s := fmt.Sprintf("%s: %s:%d %s(): '%s'", e.Name, file[len(file)-1], e.Line, e.Function, e.Message)
-
-	if e.ShowContainerSize && !e.ShowContainerAsZKeys {
-		s += fmt.Sprintf(" (cSize: %d)", e.Container.Size())
-	}
-	if e.ShowContainerItems != nil && !e.ShowContainerAsZKeys {
-		s += " (Fields:"
-		for _, i := range e.ShowContainerItems {
-			s += fmt.Sprintf(" %s: %v;", i, e.Get(i))
-		}
-		s += ")"
-	}
-
-	return s
+	return e.render(func(l *Layout) *template.Template { return l.OneLine })
 }
 
-// Pretty string for error in one line style.
+// Pretty string for error in multi line style with hints of this layer. See Layout.
 func (e *Error) MultiLinePrettyError() string {
-	s := fmt.Sprintf("%s(): %s\n\t%s:%d\n", e.Function, e.Message, e.File, e.Line)
-	if e.ShowContainerSize {
-		s += fmt.Sprintf("\tContainer size: %d\n", e.Container.Size())
-	}
-	if e.ShowContainerItems != nil {
-		s += "\tContainer fields:"
-		for _, i := range e.ShowContainerItems {
-			s += fmt.Sprintf(" %s: %v;", i, e.Get(i))
-		}
-		s += "\n"
-	}
+	return e.multiLine() + hintsString(e.ownHints(), plainPaint)
+}
 
-	return s
+// Render multi line template of error without hints.
+func (e *Error) multiLine() string {
+	return e.render(func(l *Layout) *template.Template { return l.MultiLine })
 }
 
 // Return slice of errors in correct sequence.
@@ -126,22 +132,28 @@ func (e *Error) Stack() []*Error {
 	return stack
 }
 
-// Return stack as pretty string.
+// Return stack as pretty string. Hints of the whole stack are shown at the end.
 func (e *Error) StackString() string {
 	s := ""
 
 	for _, err := range e.Stack() {
-		s += err.MultiLinePrettyError()
+		s += err.multiLine()
 	}
 
-	return s
+	return s + hintsString(e.AllHints(), plainPaint)
 }
 
 // Support for errors.Is().
-// Return true if err.Name == e.Name .
+// Return true if err.Name == e.Name or any of joined errors (see Join()) is err.
 func (e *Error) Is(err error) bool {
-	if target, ok := err.(*Error); ok {
-		return e.Name == target.Name
+	if target, ok := err.(*Error); ok && e.Name == target.Name {
+		return true
+	}
+
+	for _, j := range e.Joined {
+		if errors.Is(j, err) {
+			return true
+		}
 	}
 
 	return false
@@ -149,6 +161,10 @@ func (e *Error) Is(err error) bool {
 
 // Support errors.Unwrap().
 func (e *Error) Unwrap() error {
+	if e.Previous == nil {
+		return nil
+	}
+
 	return e.Previous
 }
 
@@ -162,6 +178,15 @@ func (e *Error) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		switch {
+		case s.Flag('+') && s.Flag('#'):
+			// Destination is unknown for fmt.State, so standard output is checked.
+			if ColorEnabled(os.Stdout) {
+				fmt.Fprintf(s, "%s", e.ColorStackString())
+			} else {
+				fmt.Fprintf(s, "%s", e.StackString())
+			}
+
+			return
 		case s.Flag('+'):
 			fmt.Fprintf(s, "%s", e.StackString())
 
@@ -201,17 +226,34 @@ func (e *Error) log(event *zerolog.Event, msg ...string) {
 			event.Str("field_"+i, fmt.Sprintf("%v", e.Get(i)))
 		}
 	}
+	if e.GoroutineID != 0 {
+		event.Uint64("goroutine", e.GoroutineID)
+		for k, v := range e.GoroutineLabels {
+			event.Str("label_"+k, v)
+		}
+	}
+	if len(e.Frames) > 0 {
+		event.Strs("frames", e.frameStrings())
+	}
 
+	if hints := e.AllHints(); len(hints) > 0 {
+		event.Array("hints", hintArray(hints))
+	}
+
+	event.Str("fingerprint", e.Fingerprint())
 	event.Str("error", e.OneLinePrettyError()).Msg(newMsg)
 }
 
 // Log with Error log level.
 func (e *Error) LogError(msg ...string) {
-	if zLog != nil {
-		if e.AddTraceToError {
+	switch {
+	case zLog != nil:
+		if e.needTrace() {
 			e.LogTraceToEvent(zLog.Error())
 		}
 		e.log(zLog.Error(), msg...)
+	case bLog != nil:
+		bLog.Print(e.basicLogMessage(e.needTrace(), msg...))
 	}
 }
 
@@ -219,29 +261,41 @@ func (e *Error) LogError(msg ...string) {
 func (e *Error) LogFatal(msg ...string) {
 	switch {
 	case zLog != nil:
-		if e.AddTraceToError {
+		if e.needTrace() {
 			e.LogTraceToEvent(zLog.Error())
 		}
 		e.log(zLog.Fatal(), msg...)
 	case bLog != nil:
-		bLog.Fatal(e.MultiLinePrettyError() + "\n" + strings.Join(msg, " "))
+		bLog.Fatal(e.basicLogMessage(e.needTrace(), msg...))
 	default:
-		log.Fatal(e.MultiLinePrettyError() + "\n" + strings.Join(msg, " "))
+		log.Fatal(e.basicLogMessage(e.needTrace(), msg...))
 	}
 }
 
+// Message for basic logger ("log" package). Use trace instead of error if withTrace is true.
+func (e *Error) basicLogMessage(withTrace bool, msg ...string) string {
+	if withTrace {
+		return e.StackString() + "\n" + strings.Join(msg, " ")
+	}
+
+	return e.multiLine() + hintsString(e.AllHints(), plainPaint) + "\n" + strings.Join(msg, " ")
+}
+
 // Log trace to event.
 func (e *Error) LogTraceToEvent(event *zerolog.Event, msg ...string) {
-	if zLog != nil {
-		newMsg := ""
-		for _, m := range msg {
-			newMsg += " " + m
-		}
-		event.Msg(newMsg + "\n" + e.StackString())
+	newMsg := ""
+	for _, m := range msg {
+		newMsg += " " + m
 	}
+	event.Msg(newMsg + "\n" + e.StackString())
 }
 
 // Log with trace log level.
 func (e *Error) LogTrace(msg ...string) {
-	e.LogTraceToEvent(zLog.Trace())
+	switch {
+	case zLog != nil:
+		e.LogTraceToEvent(zLog.Trace(), msg...)
+	case bLog != nil:
+		bLog.Print(e.basicLogMessage(true, msg...))
+	}
 }
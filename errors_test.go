@@ -171,3 +171,27 @@ func TestFormat(t *testing.T) {
 		t.Errorf("%%#v format:\n want '%s'\n  got '%s'", want, str)
 	}
 }
+
+func TestRestoreOriginal(t *testing.T) {
+	t.Parallel()
+
+	prev := goexer.New("read")
+	goexer.RestoreOriginal(prev, "disk", "")
+	if prev.Original == nil || prev.Original.Error() != "disk" {
+		t.Fatalf("Want original 'disk', got %v", prev.Original)
+	}
+
+	e := goexer.New("load")
+	e.Previous = prev
+	goexer.RestoreOriginal(e, "disk", "disk")
+	if e.Original != prev.Original {
+		t.Errorf("Want the same original as previous error, got %v", e.Original)
+	}
+
+	e = goexer.New("load")
+	e.Previous = goexer.New("read")
+	goexer.RestoreOriginal(e, "", "")
+	if e.Original != e.Previous {
+		t.Errorf("Want previous error as original, got %v", e.Original)
+	}
+}
@@ -0,0 +1,55 @@
+package goexer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"path/filepath"
+	"strconv"
+)
+
+// Options for Fingerprint(). See SetFingerprintOpts().
+type FingerprintOpts struct {
+	IgnoreLines bool // Do not use line numbers. Fingerprint is resistant to line shifts.
+	WithMessage bool // Use messages in fingerprint. MessageTemplate is used if available. Do not use it for messages with variable content.
+}
+
+var fingerprintOpts FingerprintOpts
+
+// SetFingerprintOpts - set options for Fingerprint().
+func SetFingerprintOpts(opts FingerprintOpts) {
+	fingerprintOpts = opts
+}
+
+// Fingerprint - return stable identifier of error for grouping and deduplication.
+// Fingerprint is calculated from Name and locations (function, file, line) of all errors in stack and joined errors.
+func (e *Error) Fingerprint() string {
+	h := sha256.New()
+	h.Write([]byte(e.Name))
+	e.writeFingerprint(h)
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// writeFingerprint - write data of stack to hash.
+func (e *Error) writeFingerprint(h hash.Hash) {
+	for _, err := range e.Stack() {
+		// File name only, path depends on build environment.
+		h.Write([]byte("\x00" + err.Function + "\x00" + filepath.Base(err.File)))
+		if !fingerprintOpts.IgnoreLines {
+			h.Write([]byte("\x00" + strconv.FormatUint(uint64(err.Line), 10)))
+		}
+		if fingerprintOpts.WithMessage {
+			msg := err.Message
+			if err.MessageTemplate != "" {
+				msg = err.MessageTemplate
+			}
+			h.Write([]byte("\x00" + msg))
+		}
+
+		for _, j := range err.Joined {
+			h.Write([]byte("\x00joined\x00" + j.Name))
+			j.writeFingerprint(h)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package goexer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+// Create errors with variable messages at the same place.
+func newFingerprintErrors() []*goexer.Error {
+	errs := []*goexer.Error{}
+	for _, id := range []string{"1", "2"} {
+		//nolint:goerr113
+		errs = append(errs, goexer.Wrap(errors.New("user "+id), "can't load user "+id))
+	}
+
+	return errs
+}
+
+//nolint:paralleltest
+func TestFingerprint(t *testing.T) {
+	defer goexer.SetFingerprintOpts(goexer.FingerprintOpts{})
+
+	errs := newFingerprintErrors()
+	if errs[0].Fingerprint() != errs[1].Fingerprint() {
+		t.Errorf("Errors from the same place should have the same fingerprint")
+	}
+	if len(errs[0].Fingerprint()) != 16 {
+		t.Errorf("Want 16 chars fingerprint, got '%s'", errs[0].Fingerprint())
+	}
+
+	named := goexer.New("test", goexer.ErrorOpts{Name: "NotFound"})
+	other := goexer.New("test", goexer.ErrorOpts{Name: "NotFound"})
+	if named.Fingerprint() == other.Fingerprint() {
+		t.Errorf("Errors from different lines should have different fingerprints")
+	}
+
+	goexer.SetFingerprintOpts(goexer.FingerprintOpts{IgnoreLines: true})
+	if named.Fingerprint() != other.Fingerprint() {
+		t.Errorf("Errors from different lines should have the same fingerprint with IgnoreLines")
+	}
+	if named.Fingerprint() == goexer.New("test").Fingerprint() {
+		t.Errorf("Errors with different names should have different fingerprints")
+	}
+
+	goexer.SetFingerprintOpts(goexer.FingerprintOpts{WithMessage: true})
+	if errs[0].Fingerprint() == errs[1].Fingerprint() {
+		t.Errorf("Errors with different messages should have different fingerprints with WithMessage")
+	}
+
+	goexer.SetFingerprintOpts(goexer.FingerprintOpts{})
+	joined := goexer.Join(errs[0], named)
+	if joined.Fingerprint() == goexer.Join(errs[0], other).Fingerprint() {
+		t.Errorf("Fingerprint should depend on joined errors")
+	}
+
+	j := map[string]any{}
+	data, _ := json.Marshal(named)
+	if e := json.Unmarshal(data, &j); e != nil || j["fingerprint"] != named.Fingerprint() {
+		t.Errorf("Want fingerprint in JSON, got %s", data)
+	}
+}
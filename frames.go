@@ -0,0 +1,163 @@
+package goexer
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Frame - one call frame of stack captured with ErrorOpts.CaptureFrames.
+type Frame struct {
+	Function string `json:"function,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     uint   `json:"line,omitempty"`
+	Elided   int    `json:"elided,omitempty"` // Count of filtered frames replaced by this frame. See FrameOpts.Collapse.
+}
+
+// FrameFilter - return true if frame should be hidden.
+type FrameFilter func(f Frame) bool
+
+// Options for rendering of captured frames. See SetFrameOpts().
+type FrameOpts struct {
+	Filters  []FrameFilter // Frame is hidden if any of filters returns true.
+	Collapse bool          // Replace runs of hidden frames with "... N frames elided". Otherwise hidden frames are just removed.
+}
+
+var frameOpts FrameOpts
+
+// SetFrameOpts - set options for rendering of captured frames (text and logs). Raw frames are kept in Error.Frames.
+func SetFrameOpts(opts FrameOpts) {
+	frameOpts = opts
+}
+
+// String representation of frame, e.g. "pkg.Func() file.go:10". See SetPathOpts().
+func (f Frame) String() string {
+	if f.Elided > 0 {
+		return fmt.Sprintf("... %d frames elided", f.Elided)
+	}
+
+	return fmt.Sprintf("%s() %s:%d", f.FunctionName(), f.FilePath(), f.Line)
+}
+
+// FilePath - file name with respect to SetPathOpts().
+func (f Frame) FilePath() string {
+	return filePath(f.File, f.Function)
+}
+
+// FunctionName - function name with respect to SetPathOpts().
+func (f Frame) FunctionName() string {
+	return functionName(f.Function)
+}
+
+// FilterPackages - hide frames of functions from packages with prefixes, e.g. "net/http", "github.com/org/middleware".
+func FilterPackages(prefixes ...string) FrameFilter {
+	return func(f Frame) bool {
+		pkg := funcPackage(f.Function)
+		for _, prefix := range prefixes {
+			if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterRegexp - hide frames with "function file" matching re.
+func FilterRegexp(re *regexp.Regexp) FrameFilter {
+	return func(f Frame) bool {
+		return re.MatchString(f.Function + " " + f.File)
+	}
+}
+
+// FilterStdlib - hide frames of standard library and runtime (packages without dot in the first path element).
+func FilterStdlib() FrameFilter {
+	return func(f Frame) bool {
+		pkg := funcPackage(f.Function)
+		if pkg == "" || pkg == "main" {
+			return false
+		}
+
+		first := strings.SplitN(pkg, "/", 2)[0]
+
+		return !strings.Contains(first, ".")
+	}
+}
+
+// FilterVendor - hide frames from vendor directories.
+func FilterVendor() FrameFilter {
+	return func(f Frame) bool {
+		return strings.Contains(f.File, "/vendor/")
+	}
+}
+
+// setFrames - capture up to count call frames. depth is the same as for setLocation().
+func (e *Error) setFrames(depth, count int) {
+	pc := make([]uintptr, count)
+	n := runtime.Callers(depth+2, pc) // Skip setFrames and Callers itself.
+	if n == 0 {
+		return
+	}
+	frames := runtime.CallersFrames(pc[:n])
+
+	e.Frames = make([]Frame, 0, n)
+	for {
+		frame, more := frames.Next()
+		e.Frames = append(e.Frames, Frame{Function: frame.Function, File: frame.File, Line: uint(frame.Line)})
+
+		if !more {
+			break
+		}
+	}
+}
+
+// FilteredFrames - return captured frames with filters from SetFrameOpts() applied.
+func (e *Error) FilteredFrames() []Frame {
+	frames := make([]Frame, 0, len(e.Frames))
+	elided := 0
+
+	for _, f := range e.Frames {
+		if hideFrame(f) {
+			elided++
+
+			continue
+		}
+
+		if elided > 0 && frameOpts.Collapse {
+			frames = append(frames, Frame{Elided: elided})
+		}
+		elided = 0
+
+		frames = append(frames, f)
+	}
+
+	if elided > 0 && frameOpts.Collapse {
+		frames = append(frames, Frame{Elided: elided})
+	}
+
+	return frames
+}
+
+// Check if frame should be hidden.
+func hideFrame(f Frame) bool {
+	for _, filter := range frameOpts.Filters {
+		if filter(f) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return filtered frames as strings. See FilteredFrames().
+func (e *Error) frameStrings() []string {
+	frames := e.FilteredFrames()
+	s := make([]string, 0, len(frames))
+
+	for _, f := range frames {
+		s = append(s, f.String())
+	}
+
+	return s
+}
@@ -0,0 +1,112 @@
+package goexer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/rs/zerolog"
+)
+
+func TestCaptureFrames(t *testing.T) {
+	t.Parallel()
+
+	err := goexer.New("test", goexer.ErrorOpts{CaptureFrames: 10})
+
+	if len(err.Frames) < 2 {
+		t.Fatalf("Want at least 2 frames, got %v", err.Frames)
+	}
+	if err.Frames[0].Function != err.Function || err.Frames[0].Line != err.Line {
+		t.Errorf("First frame should be location of error, got %v", err.Frames[0])
+	}
+	if err.Frames[1].Function != "testing.tRunner" {
+		t.Errorf("Want second frame testing.tRunner, got %v", err.Frames[1])
+	}
+
+	if len(goexer.New("test", goexer.ErrorOpts{CaptureFrames: 1}).Frames) != 1 {
+		t.Errorf("Want only 1 frame")
+	}
+	if goexer.New("test").Frames != nil {
+		t.Errorf("Frames should not be captured by default")
+	}
+}
+
+//nolint:paralleltest
+func TestFilteredFrames(t *testing.T) {
+	defer goexer.SetFrameOpts(goexer.FrameOpts{})
+
+	err := &goexer.Error{Frames: []goexer.Frame{
+		{Function: "github.com/org/repo.Handler", File: "/src/repo/handler.go", Line: 10},
+		{Function: "github.com/org/mw.Auth.func1", File: "/src/mw/auth.go", Line: 20},
+		{Function: "net/http.HandlerFunc.ServeHTTP", File: "/go/src/net/http/server.go", Line: 30},
+		{Function: "github.com/org/repo/vendor/x.F", File: "/src/repo/vendor/x/f.go", Line: 40},
+		{Function: "main.main", File: "/src/repo/main.go", Line: 50},
+		{Function: "runtime.main", File: "/go/src/runtime/proc.go", Line: 60},
+	}}
+
+	goexer.SetFrameOpts(goexer.FrameOpts{
+		Filters:  []goexer.FrameFilter{goexer.FilterStdlib(), goexer.FilterPackages("github.com/org/mw"), goexer.FilterVendor()},
+		Collapse: true,
+	})
+
+	want := []string{
+		"github.com/org/repo.Handler() /src/repo/handler.go:10",
+		"... 3 frames elided",
+		"main.main() /src/repo/main.go:50",
+		"... 1 frames elided",
+	}
+
+	got := []string{}
+	for _, f := range err.FilteredFrames() {
+		got = append(got, f.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Want frames:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	goexer.SetFrameOpts(goexer.FrameOpts{Filters: []goexer.FrameFilter{goexer.FilterRegexp(regexp.MustCompile(`^main\.|handler\.go$`))}})
+	if frames := err.FilteredFrames(); len(frames) != 4 || frames[0].Function != "github.com/org/mw.Auth.func1" {
+		t.Errorf("Want 4 frames without collapsing, got %v", frames)
+	}
+}
+
+//nolint:paralleltest
+func TestFramesRendering(t *testing.T) {
+	goexer.SetFrameOpts(goexer.FrameOpts{Filters: []goexer.FrameFilter{goexer.FilterStdlib()}, Collapse: true})
+	defer goexer.SetFrameOpts(goexer.FrameOpts{})
+
+	err := goexer.New("test", goexer.ErrorOpts{CaptureFrames: 10})
+
+	if !strings.Contains(err.StackString(), "\tFrames:\n\t\t"+err.Function+"() ") || !strings.Contains(err.StackString(), "frames elided\n") {
+		t.Errorf("StackString() should contain filtered frames, got:\n%s", err.StackString())
+	}
+
+	buf := &bytes.Buffer{}
+	zlog := zerolog.New(buf)
+	goexer.SetZLog(&zlog)
+	defer goexer.SetZLog(nil)
+
+	err.LogError()
+
+	rec := struct {
+		Frames []string `json:"frames"`
+	}{}
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil || len(rec.Frames) != 2 {
+		t.Errorf("Want 2 frames in log record, got '%s'", buf.String())
+	}
+
+	// JSON contains raw frames.
+	j := struct {
+		Frames []goexer.Frame `json:"frames"`
+	}{}
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e := json.Unmarshal(data, &j); e != nil || len(j.Frames) != len(err.Frames) {
+		t.Errorf("Want raw frames in JSON, got '%s'", data)
+	}
+}
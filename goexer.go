@@ -12,8 +12,10 @@ var (
 )
 
 const (
-	BaseErrorName   = "BaseError"
-	ErrorTypeString = "*goexer.Error"
+	BaseErrorName             = "BaseError"
+	CanceledErrorName         = "Canceled"         // Original error is context.Canceled.
+	DeadlineExceededErrorName = "DeadlineExceeded" // Original error is context.DeadlineExceeded.
+	ErrorTypeString           = "*goexer.Error"
 )
 
 var DefaultErrorOpts ErrorOpts = ErrorOpts{
@@ -23,4 +25,15 @@ var DefaultErrorOpts ErrorOpts = ErrorOpts{
 	ShowContainerAsZKeys: nil,
 	ShowContainerSize:    nil,
 	ShowContainerItems:   nil,
+	CaptureGoroutine:     nil,
+	Context:              nil,
+	Retryable:            nil,
+	RetryAfter:           0,
+	Severity:             SeverityDefault,
+	AddTraceToError:      nil,
+	Layout:               nil,
+	CaptureFrames:        0,
+	PublicMessage:        "",
+	PublicDetail:         "",
+	Hints:                nil,
 }
@@ -0,0 +1,285 @@
+// Package goexermsgpack - MessagePack encoding of goexer containers and errors.
+// Container items are encoded as {name, type, value} and decoded to the original Go type.
+package goexermsgpack
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Tolyar/goexer"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Go types of container values decoded natively. Values of other types are decoded to generic types (map[string]any, []any, ...).
+var itemTypes = map[string]reflect.Type{
+	"bool":                reflect.TypeOf(false),
+	"time.Time":           reflect.TypeOf(time.Time{}),
+	"time.Duration":       reflect.TypeOf(time.Duration(0)),
+	"float64":             reflect.TypeOf(float64(0)),
+	"float32":             reflect.TypeOf(float32(0)),
+	"int64":               reflect.TypeOf(int64(0)),
+	"int32":               reflect.TypeOf(int32(0)),
+	"int16":               reflect.TypeOf(int16(0)),
+	"int8":                reflect.TypeOf(int8(0)),
+	"int":                 reflect.TypeOf(0),
+	"uint64":              reflect.TypeOf(uint64(0)),
+	"uint32":              reflect.TypeOf(uint32(0)),
+	"uint16":              reflect.TypeOf(uint16(0)),
+	"uint8":               reflect.TypeOf(uint8(0)),
+	"uint":                reflect.TypeOf(uint(0)),
+	"string":              reflect.TypeOf(""),
+	"map[string]string":   reflect.TypeOf(map[string]string{}),
+	"map[string][]string": reflect.TypeOf(map[string][]string{}),
+	"map[string]bool":     reflect.TypeOf(map[string]bool{}),
+	"map[string]int":      reflect.TypeOf(map[string]int{}),
+	"map[string]int64":    reflect.TypeOf(map[string]int64{}),
+	"[]bool":              reflect.TypeOf([]bool{}),
+	"[]string":            reflect.TypeOf([]string{}),
+	"[]int":               reflect.TypeOf([]int{}),
+	"[]time.Duration":     reflect.TypeOf([]time.Duration{}),
+}
+
+// Encoded container item.
+type item struct {
+	Name  string             `msgpack:"name"`
+	Type  string             `msgpack:"type"`
+	Value msgpack.RawMessage `msgpack:"value"`
+}
+
+// Encoded call frame.
+type frame struct {
+	Function string `msgpack:"function,omitempty"`
+	File     string `msgpack:"file,omitempty"`
+	Line     uint   `msgpack:"line,omitempty"`
+	Elided   int    `msgpack:"elided,omitempty"`
+}
+
+// Encoded error.
+type errorMsg struct {
+	Name            string            `msgpack:"name"`
+	Message         string            `msgpack:"message"`
+	MessageTemplate string            `msgpack:"message_template,omitempty"`
+	Function        string            `msgpack:"function"`
+	File            string            `msgpack:"file"`
+	Line            uint              `msgpack:"line"`
+	Severity        goexer.Severity   `msgpack:"severity,omitempty"`
+	Retryable       bool              `msgpack:"retryable,omitempty"`
+	RetryAfter      time.Duration     `msgpack:"retry_after,omitempty"`
+	PublicMessage   string            `msgpack:"public_message,omitempty"`
+	PublicDetail    string            `msgpack:"public_detail,omitempty"`
+	GoroutineID     uint64            `msgpack:"goroutine,omitempty"`
+	GoroutineLabels map[string]string `msgpack:"labels,omitempty"`
+	Items           []item            `msgpack:"items,omitempty"`
+	Frames          []frame           `msgpack:"frames,omitempty"`
+	Hints           []goexer.Hint     `msgpack:"hints,omitempty"`
+	Original        string            `msgpack:"original,omitempty"` // Text of original non goexer error.
+	Previous        *errorMsg         `msgpack:"previous,omitempty"`
+	Joined          []*errorMsg       `msgpack:"joined,omitempty"`
+}
+
+// MarshalContainer - encode container to MessagePack.
+func MarshalContainer(c *goexer.Container) ([]byte, error) {
+	items, err := encodeItems(c)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := msgpack.Marshal(items)
+	if err != nil {
+		return nil, goexer.Wrap(err, "Can't marshal container")
+	}
+
+	return data, nil
+}
+
+// UnmarshalContainer - decode container encoded by MarshalContainer().
+func UnmarshalContainer(data []byte) (*goexer.Container, error) {
+	items := []item{}
+	if err := msgpack.Unmarshal(data, &items); err != nil {
+		return nil, goexer.Wrap(err, "Can't unmarshal container")
+	}
+
+	return decodeItems(items)
+}
+
+// Marshal - encode e with its chain to MessagePack. Layouts and rendering options are not encoded.
+func Marshal(e *goexer.Error) ([]byte, error) {
+	m, err := encodeError(e)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := msgpack.Marshal(m)
+	if err != nil {
+		return nil, goexer.Wrap(err, "Can't marshal error")
+	}
+
+	return data, nil
+}
+
+// Unmarshal - decode error encoded by Marshal(). Original non goexer error is restored as error with the same text.
+func Unmarshal(data []byte) (*goexer.Error, error) {
+	m := &errorMsg{}
+	if err := msgpack.Unmarshal(data, m); err != nil {
+		return nil, goexer.Wrap(err, "Can't unmarshal error")
+	}
+
+	return decodeError(m)
+}
+
+// Encode items of container. Values are encoded as is.
+func encodeItems(c *goexer.Container) ([]item, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	items := make([]item, 0, c.Size())
+
+	for _, k := range c.Keys() {
+		i, _ := c.GetItem(k)
+
+		v, err := msgpack.Marshal(i.Value)
+		if err != nil {
+			return nil, goexer.Wrap(err, "Can't marshal container item", goexer.ErrorOpts{
+				Container: goexer.NewContainer().Set("item", k),
+			})
+		}
+
+		items = append(items, item{Name: i.Name, Type: i.Type, Value: v})
+	}
+
+	return items, nil
+}
+
+// Decode items to container. Values of known types are decoded to the original type.
+func decodeItems(items []item) (*goexer.Container, error) {
+	c := goexer.NewContainer()
+
+	for _, i := range items {
+		var value any
+
+		if t, ok := itemTypes[i.Type]; ok {
+			v := reflect.New(t)
+			if err := msgpack.Unmarshal(i.Value, v.Interface()); err != nil {
+				return nil, goexer.Wrap(err, "Can't unmarshal container item", goexer.ErrorOpts{
+					Container: goexer.NewContainer().Set("item", i.Name).Set("type", i.Type),
+				})
+			}
+			value = v.Elem().Interface()
+		} else if err := msgpack.Unmarshal(i.Value, &value); err != nil {
+			return nil, goexer.Wrap(err, "Can't unmarshal container item", goexer.ErrorOpts{
+				Container: goexer.NewContainer().Set("item", i.Name).Set("type", i.Type),
+			})
+		}
+
+		c.SetItem(goexer.Item{Name: i.Name, Type: i.Type, Value: value})
+	}
+
+	return c, nil
+}
+
+// Convert error with its chain to encoded form.
+func encodeError(e *goexer.Error) (*errorMsg, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	items, err := encodeItems(e.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &errorMsg{
+		Name:            e.Name,
+		Message:         e.Message,
+		MessageTemplate: e.MessageTemplate,
+		Function:        e.Function,
+		File:            e.File,
+		Line:            e.Line,
+		Severity:        e.Severity,
+		Retryable:       e.Retryable,
+		RetryAfter:      e.RetryAfter,
+		PublicMessage:   e.PublicMessage,
+		PublicDetail:    e.PublicDetail,
+		GoroutineID:     e.GoroutineID,
+		GoroutineLabels: e.GoroutineLabels,
+		Items:           items,
+		Hints:           e.Hints,
+	}
+
+	for _, f := range e.Frames {
+		m.Frames = append(m.Frames, frame(f))
+	}
+
+	if e.Original != nil && !goexer.IsGoexerError(e.Original) {
+		m.Original = e.Original.Error()
+	}
+
+	if m.Previous, err = encodeError(e.Previous); err != nil {
+		return nil, err
+	}
+
+	for _, j := range e.Joined {
+		jm, err := encodeError(j)
+		if err != nil {
+			return nil, err
+		}
+		m.Joined = append(m.Joined, jm)
+	}
+
+	return m, nil
+}
+
+// Convert encoded error with its chain to *goexer.Error.
+func decodeError(m *errorMsg) (*goexer.Error, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	c, err := decodeItems(m.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &goexer.Error{
+		Name:            m.Name,
+		Message:         m.Message,
+		MessageTemplate: m.MessageTemplate,
+		Function:        m.Function,
+		File:            m.File,
+		Line:            m.Line,
+		Container:       c,
+		Severity:        m.Severity,
+		Retryable:       m.Retryable,
+		RetryAfter:      m.RetryAfter,
+		PublicMessage:   m.PublicMessage,
+		PublicDetail:    m.PublicDetail,
+		GoroutineID:     m.GoroutineID,
+		GoroutineLabels: m.GoroutineLabels,
+		Hints:           m.Hints,
+	}
+
+	for _, f := range m.Frames {
+		e.Frames = append(e.Frames, goexer.Frame(f))
+	}
+
+	if e.Previous, err = decodeError(m.Previous); err != nil {
+		return nil, err
+	}
+
+	for _, jm := range m.Joined {
+		j, err := decodeError(jm)
+		if err != nil {
+			return nil, err
+		}
+		e.Joined = append(e.Joined, j)
+	}
+
+	prevOriginal := ""
+	if m.Previous != nil {
+		prevOriginal = m.Previous.Original
+	}
+	goexer.RestoreOriginal(e, m.Original, prevOriginal)
+
+	return e, nil
+}
@@ -0,0 +1,198 @@
+package goexermsgpack_test
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+	"github.com/Tolyar/goexer/goexermsgpack"
+)
+
+type point struct {
+	X, Y int
+}
+
+// Container with values of all types from goexer.Item.Get().
+func testContainer() *goexer.Container {
+	return goexer.NewContainer().
+		Set("bool", true).
+		Set("time", time.Date(2023, 4, 5, 6, 7, 8, 9, time.UTC)).
+		Set("duration", 90*time.Second).
+		Set("float64", 1.25).
+		Set("float32", float32(2.5)).
+		Set("int64", int64(math.MaxInt64)).
+		Set("int32", int32(-10)).
+		Set("int16", int16(300)).
+		Set("int8", int8(-3)).
+		Set("int", 42).
+		Set("uint64", uint64(math.MaxUint64)).
+		Set("uint32", uint32(7)).
+		Set("uint16", uint16(8)).
+		Set("uint8", uint8(9)).
+		Set("uint", uint(10)).
+		Set("string", "value").
+		Set("map[string]string", map[string]string{"a": "b"}).
+		Set("map[string][]string", map[string][]string{"a": {"b", "c"}}).
+		Set("map[string]bool", map[string]bool{"a": true}).
+		Set("map[string]int", map[string]int{"a": 1}).
+		Set("map[string]int64", map[string]int64{"a": math.MaxInt64}).
+		Set("[]bool", []bool{true, false}).
+		Set("[]string", []string{"a", "b"}).
+		Set("[]int", []int{1, 2}).
+		Set("[]time.Duration", []time.Duration{time.Second, time.Minute})
+}
+
+func TestContainerRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	c := testContainer()
+
+	data, err := goexermsgpack.MarshalContainer(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := goexermsgpack.UnmarshalContainer(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Size() != c.Size() {
+		t.Fatalf("Want %d items, got %d", c.Size(), got.Size())
+	}
+
+	for _, k := range c.Keys() {
+		want, _ := c.GetItem(k)
+		item, _ := got.GetItem(k)
+
+		if item.Type != want.Type || reflect.TypeOf(item.Value) != reflect.TypeOf(want.Value) {
+			t.Errorf("%s: want %s (%T), got %s (%T)", k, want.Type, want.Value, item.Type, item.Value)
+		}
+
+		if wt, ok := want.Value.(time.Time); ok {
+			if !wt.Equal(item.Value.(time.Time)) {
+				t.Errorf("%s: want %v, got %v", k, wt, item.Value)
+			}
+
+			continue
+		}
+
+		if !reflect.DeepEqual(item.Value, want.Value) {
+			t.Errorf("%s: want %v, got %v", k, want.Value, item.Value)
+		}
+	}
+}
+
+func TestContainerUnknownType(t *testing.T) {
+	t.Parallel()
+
+	data, err := goexermsgpack.MarshalContainer(goexer.NewContainer().Set("point", point{X: 1, Y: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := goexermsgpack.UnmarshalContainer(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, _ := got.GetItem("point")
+	if item.Type != "goexermsgpack_test.point" {
+		t.Errorf("Want type goexermsgpack_test.point, got %s", item.Type)
+	}
+
+	if m, ok := item.Value.(map[string]any); !ok || len(m) != 2 {
+		t.Errorf("Want generic map, got %#v", item.Value)
+	}
+}
+
+func TestErrorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tr := true
+	inner := goexer.Wrap(io.ErrUnexpectedEOF, "read body", goexer.ErrorOpts{
+		Name:          "ReadError",
+		Container:     testContainer(),
+		CaptureFrames: 4,
+	}).AddHint("Check network", "")
+	err := goexer.Wrap(inner, "can't handle request", goexer.ErrorOpts{Retryable: &tr, RetryAfter: time.Minute})
+	err.Joined = []*goexer.Error{goexer.New("joined")}
+
+	data, e := goexermsgpack.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	got, e := goexermsgpack.Unmarshal(data)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	want, _ := json.Marshal(err)
+	if j, _ := json.Marshal(got); string(j) != string(want) {
+		t.Errorf("Want %s, got %s", want, j)
+	}
+
+	if got.Previous.Get("uint64") != uint64(math.MaxUint64) {
+		t.Errorf("Want max uint64, got %v", got.Previous.Get("uint64"))
+	}
+
+	if got.Original == nil || got.Original != got.Previous.Original || got.Original.Error() != io.ErrUnexpectedEOF.Error() {
+		t.Errorf("Want shared original error, got %v and %v", got.Original, got.Previous.Original)
+	}
+
+	if _, e := goexermsgpack.Unmarshal([]byte{0xc1}); e == nil {
+		t.Errorf("Want error for invalid data")
+	}
+}
+
+func BenchmarkContainerMsgpack(b *testing.B) {
+	c := testContainer()
+
+	data, _ := goexermsgpack.MarshalContainer(c)
+
+	b.Run("Marshal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := goexermsgpack.MarshalContainer(c); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(len(data)), "size")
+	})
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := goexermsgpack.UnmarshalContainer(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkContainerJSON(b *testing.B) {
+	c := testContainer()
+
+	data, _ := c.JSON()
+
+	b.Run("Marshal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.JSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(len(data)), "size")
+	})
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := goexer.Marshaled{}
+			if err := json.Unmarshal(data, &m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package goexerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative error.proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Tolyar/goexer"
+	"google.golang.org/protobuf/proto"
+)
+
+// ToProto - convert e with its chain to protobuf message. Layouts and rendering options are not converted.
+func ToProto(e *goexer.Error) *Error {
+	if e == nil {
+		return nil
+	}
+
+	p := &Error{
+		Name:            e.Name,
+		Code:            int32(httpStatus(e.Name)),
+		Message:         e.Message,
+		MessageTemplate: e.MessageTemplate,
+		Location:        &Frame{Function: e.Function, File: e.File, Line: uint32(e.Line)},
+		Previous:        ToProto(e.Previous),
+		Severity:        int32(e.Severity),
+		Retryable:       e.Retryable,
+		RetryAfter:      int64(e.RetryAfter),
+		PublicMessage:   e.PublicMessage,
+		PublicDetail:    e.PublicDetail,
+		GoroutineId:     e.GoroutineID,
+		GoroutineLabels: e.GoroutineLabels,
+	}
+
+	for _, f := range e.Frames {
+		p.Frames = append(p.Frames, &Frame{Function: f.Function, File: f.File, Line: uint32(f.Line), Elided: int32(f.Elided)})
+	}
+
+	if e.Container != nil {
+		for _, k := range e.Container.Keys() {
+			item, _ := e.Container.GetItem(k)
+			p.Items = append(p.Items, &Item{Name: item.Name, Type: item.Type, Value: toValue(item.Value)})
+		}
+	}
+
+	for _, j := range e.Joined {
+		p.Joined = append(p.Joined, ToProto(j))
+	}
+
+	for _, h := range e.Hints {
+		p.Hints = append(p.Hints, &Hint{Text: h.Text, Url: h.URL})
+	}
+
+	if e.Original != nil && !goexer.IsGoexerError(e.Original) {
+		p.Original = e.Original.Error()
+	}
+
+	return p
+}
+
+// Return HTTP status of kind. See goexer.KindOpts.HTTPStatus.
+func httpStatus(name string) int {
+	if kind, ok := goexer.LookupKind(name); ok && kind.HTTPStatus != 0 {
+		return kind.HTTPStatus
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Return Value for container item value.
+func toValue(v any) *Value {
+	switch v := v.(type) {
+	case bool:
+		return &Value{Kind: &Value_BoolValue{BoolValue: v}}
+	case int:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}
+	case int8:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}
+	case int16:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}
+	case int32:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}
+	case int64:
+		return &Value{Kind: &Value_IntValue{IntValue: v}}
+	case time.Duration:
+		return &Value{Kind: &Value_IntValue{IntValue: int64(v)}}
+	case uint:
+		return &Value{Kind: &Value_UintValue{UintValue: uint64(v)}}
+	case uint8:
+		return &Value{Kind: &Value_UintValue{UintValue: uint64(v)}}
+	case uint16:
+		return &Value{Kind: &Value_UintValue{UintValue: uint64(v)}}
+	case uint32:
+		return &Value{Kind: &Value_UintValue{UintValue: uint64(v)}}
+	case uint64:
+		return &Value{Kind: &Value_UintValue{UintValue: v}}
+	case float32:
+		return &Value{Kind: &Value_FloatValue{FloatValue: float64(v)}}
+	case float64:
+		return &Value{Kind: &Value_FloatValue{FloatValue: v}}
+	case string:
+		return &Value{Kind: &Value_StringValue{StringValue: v}}
+	case time.Time:
+		return &Value{Kind: &Value_StringValue{StringValue: v.Format(time.RFC3339Nano)}}
+	}
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		return &Value{Kind: &Value_StringValue{StringValue: fmt.Sprintf("%v", v)}}
+	}
+
+	return &Value{Kind: &Value_JsonValue{JsonValue: j}}
+}
+
+// Return raw value of Value. JSON is decoded to generic types (map[string]any, []any, ...).
+func fromValue(v *Value) any {
+	switch k := v.GetKind().(type) {
+	case *Value_BoolValue:
+		return k.BoolValue
+	case *Value_IntValue:
+		return k.IntValue
+	case *Value_UintValue:
+		return k.UintValue
+	case *Value_FloatValue:
+		return k.FloatValue
+	case *Value_StringValue:
+		return k.StringValue
+	case *Value_JsonValue:
+		var raw any
+		if err := json.Unmarshal(k.JsonValue, &raw); err != nil {
+			return string(k.JsonValue)
+		}
+
+		return raw
+	default:
+		return nil
+	}
+}
+
+// FromProto - convert protobuf message to *goexer.Error.
+// Container items keep original type: values of known types (see goexer.Item.Get()) are converted back to it.
+// Original non goexer error is restored as error with the same text.
+func FromProto(p *Error) *goexer.Error {
+	if p == nil {
+		return nil
+	}
+
+	e := &goexer.Error{
+		Name:            p.GetName(),
+		Message:         p.GetMessage(),
+		MessageTemplate: p.GetMessageTemplate(),
+		Function:        p.GetLocation().GetFunction(),
+		File:            p.GetLocation().GetFile(),
+		Line:            uint(p.GetLocation().GetLine()),
+		Previous:        FromProto(p.GetPrevious()),
+		Container:       goexer.NewContainer(),
+		Severity:        goexer.Severity(p.GetSeverity()),
+		Retryable:       p.GetRetryable(),
+		RetryAfter:      time.Duration(p.GetRetryAfter()),
+		PublicMessage:   p.GetPublicMessage(),
+		PublicDetail:    p.GetPublicDetail(),
+		GoroutineID:     p.GetGoroutineId(),
+		GoroutineLabels: p.GetGoroutineLabels(),
+	}
+
+	for _, f := range p.GetFrames() {
+		e.Frames = append(e.Frames, goexer.Frame{
+			Function: f.GetFunction(), File: f.GetFile(), Line: uint(f.GetLine()), Elided: int(f.GetElided()),
+		})
+	}
+
+	for _, i := range p.GetItems() {
+		item := goexer.Item{Name: i.GetName(), Type: i.GetType(), Value: fromValue(i.GetValue())}
+		item.Value = item.Get()
+		e.Container.SetItem(item)
+	}
+
+	for _, j := range p.GetJoined() {
+		e.Joined = append(e.Joined, FromProto(j))
+	}
+
+	for _, h := range p.GetHints() {
+		e.Hints = append(e.Hints, goexer.Hint{Text: h.GetText(), URL: h.GetUrl()})
+	}
+
+	goexer.RestoreOriginal(e, p.GetOriginal(), p.GetPrevious().GetOriginal())
+
+	return e
+}
+
+// Marshal - encode e with its chain to protobuf binary format.
+func Marshal(e *goexer.Error) ([]byte, error) {
+	data, err := proto.Marshal(ToProto(e))
+	if err != nil {
+		return nil, goexer.Wrap(err, "Can't marshal error")
+	}
+
+	return data, nil
+}
+
+// Unmarshal - decode error encoded by Marshal().
+func Unmarshal(data []byte) (*goexer.Error, error) {
+	p := &Error{}
+	if err := proto.Unmarshal(data, p); err != nil {
+		return nil, goexer.Wrap(err, "Can't unmarshal error")
+	}
+
+	return FromProto(p), nil
+}
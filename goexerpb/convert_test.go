@@ -0,0 +1,84 @@
+package goexerpb_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+	"github.com/Tolyar/goexer/goexerpb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tr := true
+	c := goexer.NewContainer().
+		Set("int32", int32(10)).
+		Set("uint8", uint8(3)).
+		Set("float32", float32(1.5)).
+		Set("duration", time.Second).
+		Set("time", time.Date(2023, 4, 5, 6, 7, 8, 9, time.UTC)).
+		Set("strings", []string{"a", "b"}).
+		Set("map", map[string]int{"a": 1}).
+		Set("bool", true)
+
+	inner := goexer.Wrap(io.ErrUnexpectedEOF, "read body", goexer.ErrorOpts{Name: "ReadError", Container: c, CaptureFrames: 4})
+	inner.AddHint("Check network", "https://example.com/net")
+	err := goexer.Wrap(inner, "can't handle request", goexer.ErrorOpts{
+		Retryable:     &tr,
+		RetryAfter:    time.Minute,
+		PublicMessage: "Try later",
+	})
+	err.Joined = []*goexer.Error{goexer.New("joined")}
+
+	data, e := goexerpb.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	got, e := goexerpb.Unmarshal(data)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	want, _ := json.Marshal(err)
+	if j, _ := json.Marshal(got); string(j) != string(want) {
+		t.Errorf("Want %s, got %s", want, j)
+	}
+
+	for _, k := range c.Keys() {
+		wi, _ := c.GetItem(k)
+		gi, _ := got.Previous.Container.GetItem(k)
+		if gi.Type != wi.Type || reflect.TypeOf(gi.Value) != reflect.TypeOf(wi.Value) {
+			t.Errorf("%s: want %s (%T), got %s (%T)", k, wi.Type, wi.Value, gi.Type, gi.Value)
+		}
+	}
+
+	if got.Previous.Get("int32") != int32(10) || !reflect.DeepEqual(got.Previous.Get("strings"), []string{"a", "b"}) {
+		t.Errorf("Want typed values, got %v", got.Previous.Container.Marshaled())
+	}
+
+	if got.Original == nil || got.Original.Error() != io.ErrUnexpectedEOF.Error() || got.Original != got.Previous.Original {
+		t.Errorf("Want shared original error, got %v and %v", got.Original, got.Previous.Original)
+	}
+
+	if !errors.Is(got, inner) || got.Fingerprint() != err.Fingerprint() {
+		t.Errorf("Want the same error")
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := goexerpb.Unmarshal([]byte{0xff}); err == nil {
+		t.Errorf("Want error for invalid data")
+	}
+
+	if goexerpb.ToProto(nil) != nil || goexerpb.FromProto(nil) != nil {
+		t.Errorf("Want nil for nil")
+	}
+}
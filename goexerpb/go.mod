@@ -0,0 +1,18 @@
+module github.com/Tolyar/goexer/goexerpb
+
+go 1.19
+
+require (
+	github.com/Tolyar/goexer v0.0.0-20261019015205-0227a0aaea2f
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/rs/zerolog v1.29.0 // indirect
+	github.com/samber/lo v1.37.0 // indirect
+	github.com/spf13/cast v1.5.0 // indirect
+	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+)
@@ -0,0 +1,81 @@
+package goexer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+)
+
+// Return id of current goroutine. Returns 0 if id can't be parsed.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// Stack always starts with "goroutine <id> [<status>]:".
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+
+	i := bytes.IndexByte(buf, ' ')
+	if i < 0 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(string(buf[:i]), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
+
+// Return pprof labels stored in context. Returns nil if context is nil or has no labels.
+func goroutineLabels(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+
+	var labels map[string]string
+
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[key] = value
+
+		return true
+	})
+
+	return labels
+}
+
+// setGoroutine - record goroutine where error was created.
+func (e *Error) setGoroutine(ctx context.Context) {
+	e.GoroutineID = goroutineID()
+	e.GoroutineLabels = goroutineLabels(ctx)
+}
+
+// Return goroutine id and labels as string. E.g. "12 (labels: a=b, c=d)".
+func (e *Error) GoroutineString() string {
+	s := strconv.FormatUint(e.GoroutineID, 10)
+
+	if len(e.GoroutineLabels) > 0 {
+		keys := make([]string, 0, len(e.GoroutineLabels))
+		for k := range e.GoroutineLabels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		s += " (labels:"
+		for i, k := range keys {
+			if i > 0 {
+				s += ","
+			}
+			s += fmt.Sprintf(" %s=%s", k, e.GoroutineLabels[k])
+		}
+		s += ")"
+	}
+
+	return s
+}
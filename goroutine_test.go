@@ -0,0 +1,50 @@
+package goexer_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestCaptureGoroutine(t *testing.T) {
+	t.Parallel()
+
+	capture := true
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("worker", "w1", "job", "42"))
+
+	ch := make(chan *goexer.Error)
+	go func() {
+		ch <- goexer.New("from goroutine", goexer.ErrorOpts{CaptureGoroutine: &capture, Context: ctx})
+	}()
+	err := <-ch
+
+	if err.GoroutineID == 0 {
+		t.Errorf("Want goroutine id > 0, got '%d'", err.GoroutineID)
+	}
+	if err.GoroutineLabels["worker"] != "w1" || err.GoroutineLabels["job"] != "42" {
+		t.Errorf("Want labels worker=w1 job=42, got '%v'", err.GoroutineLabels)
+	}
+
+	want := "(labels: job=42, worker=w1)"
+	if !strings.HasSuffix(err.GoroutineString(), want) {
+		t.Errorf("Want GoroutineString() with suffix '%s', got '%s'", want, err.GoroutineString())
+	}
+	if !strings.Contains(err.StackString(), "\tGoroutine: "+err.GoroutineString()+"\n") {
+		t.Errorf("StackString() should contain goroutine info, got '%s'", err.StackString())
+	}
+}
+
+func TestCaptureGoroutineDisabled(t *testing.T) {
+	t.Parallel()
+
+	err := goexer.New("test")
+	if err.GoroutineID != 0 || err.GoroutineLabels != nil {
+		t.Errorf("Want empty goroutine info, got '%d' '%v'", err.GoroutineID, err.GoroutineLabels)
+	}
+	if strings.Contains(err.StackString(), "Goroutine:") {
+		t.Errorf("StackString() should not contain goroutine info, got '%s'", err.StackString())
+	}
+}
@@ -0,0 +1,199 @@
+package goexer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PanicErrorName - kind of errors recovered from panics in Group tasks.
+const PanicErrorName = "Panic"
+
+// GroupMode - how Group handles failed tasks.
+type GroupMode int8
+
+const (
+	GroupCollectAll GroupMode = iota // Run all tasks and collect all errors. Default.
+	GroupFailFast                    // Cancel context on the first error, tasks added after it are not started.
+)
+
+// Options for NewGroup().
+type GroupOpts struct {
+	Mode  GroupMode
+	Limit int // Max count of concurrently running tasks. 0 - without limit.
+}
+
+// Group - runs tasks in goroutines and collects their errors into joined *Error, like errgroup.Group.
+// Each failed task is a joined error (see Error.Joined) with "task" (name) and "task_index" fields.
+// Panics in tasks are recovered into errors of PanicErrorName kind.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   GroupOpts
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mu     sync.Mutex
+	count  int
+	failed bool
+	errs   []groupError
+}
+
+// Error of task with its index.
+type groupError struct {
+	index int
+	err   *Error
+}
+
+// NewGroup - create Group and derived context which is canceled when Wait() returns
+// or, in GroupFailFast mode, on the first error.
+func NewGroup(ctx context.Context, args ...GroupOpts) (*Group, context.Context) {
+	if len(args) > 1 {
+		fatal(New("Only one or zero GroupOpts could be passed to NewGroup()"), "Only one or zero GroupOpts could be passed to NewGroup()")
+	}
+
+	opts := GroupOpts{}
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	g := &Group{opts: opts}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+
+	if opts.Limit > 0 {
+		g.sem = make(chan struct{}, opts.Limit)
+	}
+
+	return g, g.ctx
+}
+
+// Go - run task fn in goroutine. name is used in "task" field of error.
+// Blocks until a slot is available if GroupOpts.Limit is set.
+// In GroupFailFast mode task is not started if group has already failed.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	index := g.count
+	g.count++
+	skip := g.failed && g.opts.Mode == GroupFailFast
+	g.mu.Unlock()
+
+	if skip {
+		g.release()
+
+		return
+	}
+
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+		defer g.release()
+
+		if err := g.run(fn); err != nil {
+			g.add(index, name, err)
+		}
+	}()
+}
+
+// Release slot of task. See GroupOpts.Limit.
+func (g *Group) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
+
+// Run task and recover panic.
+func (g *Group) run(fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e := newError(2, fmt.Sprintf("panic: %v", r), ErrorOpts{Name: PanicErrorName, CaptureFrames: 32})
+			if re, ok := r.(error); ok {
+				e.Original = re
+			}
+			e.setPanicLocation()
+			err = e
+		}
+	}()
+
+	return fn(g.ctx)
+}
+
+// Record error of task.
+func (g *Group) add(index int, name string, err error) {
+	var e *Error
+	if IsGoexerError(err) {
+		// Copy of the layer, task could return shared error (e.g. package level sentinel).
+		c := *ToError(err)
+		e = &c
+	} else {
+		e = foreignError(2, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Cancellation caused by the first error is not a failure of task.
+	if g.failed && g.opts.Mode == GroupFailFast && e.Name == CanceledErrorName {
+		return
+	}
+
+	e.addFields(map[string]any{"task": name, "task_index": index})
+	g.errs = append(g.errs, groupError{index: index, err: e})
+
+	if !g.failed {
+		g.failed = true
+		if g.opts.Mode == GroupFailFast {
+			g.cancel()
+		}
+	}
+}
+
+// Wait - wait for all started tasks and return joined error (*Error, see Join()) of failed tasks ordered by task index.
+// Returns nil error (not typed nil) if all tasks succeeded. Context of group is canceled.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sort.Slice(g.errs, func(i, j int) bool { return g.errs[i].index < g.errs[j].index })
+
+	errs := make([]error, 0, len(g.errs))
+	for _, ge := range g.errs {
+		errs = append(errs, ge.err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return join(3, errs)
+}
+
+// setPanicLocation - set location to the frame where panic was raised. Frames of recover handler are removed.
+func (e *Error) setPanicLocation() {
+	for i, f := range e.Frames {
+		if f.Function != "runtime.gopanic" {
+			continue
+		}
+
+		for _, pf := range e.Frames[i+1:] {
+			if !strings.HasPrefix(pf.Function, "runtime.") {
+				e.Function, e.File, e.Line = pf.Function, pf.File, pf.Line
+
+				break
+			}
+		}
+		e.Frames = e.Frames[i+1:]
+
+		return
+	}
+}
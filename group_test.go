@@ -0,0 +1,165 @@
+package goexer_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestGroupCollectAll(t *testing.T) {
+	t.Parallel()
+
+	g, _ := goexer.NewGroup(context.Background())
+
+	g.Go("ok", func(ctx context.Context) error { return nil })
+	g.Go("db", func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+
+		return goexer.New("db is down")
+	})
+	g.Go("cache", func(ctx context.Context) error { return errors.New("cache miss") }) //nolint:goerr113
+	g.Go("parser", func(ctx context.Context) error { panic("nil map") })
+
+	var err *goexer.Error
+	if !errors.As(g.Wait(), &err) || len(err.Joined) != 3 {
+		t.Fatalf("Want 3 joined errors, got %v", err)
+	}
+
+	want := []struct {
+		Task    string
+		Index   int
+		Message string
+	}{
+		{"db", 1, "db is down"},
+		{"cache", 2, "cache miss"},
+		{"parser", 3, "panic: nil map"},
+	}
+
+	for i, w := range want {
+		j := err.Joined[i]
+		if j.Get("task") != w.Task || j.Get("task_index") != w.Index || j.Message != w.Message {
+			t.Errorf("Want %s/%d '%s', got %v/%v '%s'", w.Task, w.Index, w.Message, j.Get("task"), j.Get("task_index"), j.Message)
+		}
+	}
+
+	p := err.Joined[2]
+	if p.Name != goexer.PanicErrorName || p.Severity != goexer.SeverityCritical || len(p.Frames) == 0 {
+		t.Errorf("Want Panic with frames, got %s %s %d", p.Name, p.Severity, len(p.Frames))
+	}
+	if !strings.HasSuffix(p.File, "group_test.go") {
+		t.Errorf("Want location of panic, got %s:%d %s", p.File, p.Line, p.Function)
+	}
+}
+
+func TestGroupFailFast(t *testing.T) {
+	t.Parallel()
+
+	g, ctx := goexer.NewGroup(context.Background(), goexer.GroupOpts{Mode: goexer.GroupFailFast})
+
+	var started int32
+
+	g.Go("first", func(ctx context.Context) error {
+		atomic.AddInt32(&started, 1)
+
+		return goexer.New("failed")
+	})
+	g.Go("second", func(ctx context.Context) error {
+		atomic.AddInt32(&started, 1)
+		<-ctx.Done()
+
+		return goexer.Wrap(ctx.Err(), "second canceled")
+	})
+
+	var err *goexer.Error
+	if !errors.As(g.Wait(), &err) || len(err.Joined) != 1 || err.Joined[0].Get("task") != "first" {
+		t.Fatalf("Want only error of first task, got %v", err)
+	}
+
+	if ctx.Err() == nil {
+		t.Errorf("Want canceled context")
+	}
+
+	g.Go("third", func(ctx context.Context) error {
+		atomic.AddInt32(&started, 1)
+
+		return nil
+	})
+	if atomic.LoadInt32(&started) != 2 {
+		t.Errorf("Want third task not started")
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	t.Parallel()
+
+	g, _ := goexer.NewGroup(context.Background(), goexer.GroupOpts{Limit: 2})
+
+	var running, peak int32
+
+	for i := 0; i < 6; i++ {
+		g.Go("task", func(ctx context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil || atomic.LoadInt32(&peak) > 2 {
+		t.Errorf("Want at most 2 running tasks, got %d (%v)", peak, err)
+	}
+}
+
+func TestGroupSuccess(t *testing.T) {
+	t.Parallel()
+
+	g, _ := goexer.NewGroup(context.Background())
+	g.Go("ok", func(ctx context.Context) error { return nil })
+
+	// Wait() returns error, so success is untyped nil.
+	if err := g.Wait(); err != nil {
+		t.Errorf("Want nil error, got %#v", err)
+	}
+}
+
+var errGroupSentinel = goexer.New("sentinel", goexer.ErrorOpts{Name: "GroupTestSentinel"})
+
+func TestGroupSentinel(t *testing.T) {
+	t.Parallel()
+
+	g, _ := goexer.NewGroup(context.Background())
+
+	g.Go("a", func(ctx context.Context) error { return errGroupSentinel })
+	g.Go("b", func(ctx context.Context) error { return errGroupSentinel })
+
+	var err *goexer.Error
+	if !errors.As(g.Wait(), &err) || len(err.Joined) != 2 {
+		t.Fatalf("Want 2 joined errors, got %v", err)
+	}
+
+	if err.Joined[0] == err.Joined[1] {
+		t.Error("Joined errors of tasks should be different")
+	}
+	for i, task := range []string{"a", "b"} {
+		j := err.Joined[i]
+		if j.Get("task") != task || j.Get("task_index") != i || !errors.Is(j, errGroupSentinel) {
+			t.Errorf("Want %s/%d, got %v/%v", task, i, j.Get("task"), j.Get("task_index"))
+		}
+	}
+
+	if _, ok := errGroupSentinel.GetE("task"); ok {
+		t.Errorf("Sentinel should not be changed, got %v", errGroupSentinel.Container.Marshaled())
+	}
+}
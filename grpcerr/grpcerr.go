@@ -0,0 +1,93 @@
+// Package grpcerr - conversion of goexer errors to gRPC statuses and back.
+package grpcerr
+
+import (
+	"errors"
+
+	"github.com/Tolyar/goexer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	kindCodes = map[string]codes.Code{
+		goexer.CanceledErrorName:             codes.Canceled,
+		goexer.DeadlineExceededErrorName:     codes.DeadlineExceeded,
+		goexer.NotFoundErrorName:             codes.NotFound,
+		goexer.UniqueViolationErrorName:      codes.AlreadyExists,
+		goexer.ForeignKeyViolationErrorName:  codes.FailedPrecondition,
+		goexer.IntegrityViolationErrorName:   codes.FailedPrecondition,
+		goexer.NotNullViolationErrorName:     codes.InvalidArgument,
+		goexer.CheckViolationErrorName:       codes.InvalidArgument,
+		goexer.DeadlockErrorName:             codes.Aborted,
+		goexer.SerializationFailureErrorName: codes.Aborted,
+		goexer.PermissionDeniedErrorName:     codes.PermissionDenied,
+		goexer.AlreadyExistsErrorName:        codes.AlreadyExists,
+		goexer.TimeoutErrorName:              codes.DeadlineExceeded,
+		goexer.ConnectionRefusedErrorName:    codes.Unavailable,
+		goexer.ValidationErrorName:           codes.InvalidArgument,
+		goexer.InvalidFieldErrorName:         codes.InvalidArgument,
+	}
+	// Kinds for errors received without goexer details. See FromStatus().
+	codeKinds = map[codes.Code]string{
+		codes.Canceled:         goexer.CanceledErrorName,
+		codes.DeadlineExceeded: goexer.DeadlineExceededErrorName,
+		codes.NotFound:         goexer.NotFoundErrorName,
+		codes.PermissionDenied: goexer.PermissionDeniedErrorName,
+		codes.AlreadyExists:    goexer.AlreadyExistsErrorName,
+	}
+)
+
+// RegisterCode - set gRPC code for errors of kind.
+// The first kind registered for code is used for errors received without goexer details.
+// Should be called before errors are converted (e.g. in init()).
+func RegisterCode(kind string, code codes.Code) {
+	kindCodes[kind] = code
+	if _, ok := codeKinds[code]; !ok {
+		codeKinds[code] = kind
+	}
+}
+
+// Code - return gRPC code for err: code of the outer error in stack with registered kind
+// or code of received status (see FromStatus()). Returns codes.Unknown if there is no code.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if !goexer.IsGoexerError(err) {
+		return codes.Unknown
+	}
+
+	for e := goexer.ToError(err); e != nil; e = e.Previous {
+		if code, ok := kindCodes[e.Name]; ok {
+			return code
+		}
+
+		var se interface{ GRPCStatus() *status.Status }
+		if e.Original != nil && errors.As(e.Original, &se) {
+			return se.GRPCStatus().Code()
+		}
+	}
+
+	return codes.Unknown
+}
+
+// PublicStatus - return gRPC status with public parts of err only (see goexer.Public()).
+// Code name is used as message if there is no public message.
+func PublicStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	code := Code(err)
+
+	msg, detail := goexer.Public(err)
+	if msg == "" {
+		msg = code.String()
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+
+	return status.New(code, msg)
+}
@@ -0,0 +1,34 @@
+package grpcerr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/Tolyar/goexer/grpcerr"
+	"google.golang.org/grpc/codes"
+)
+
+//nolint:paralleltest
+func TestPublicStatus(t *testing.T) {
+	grpcerr.RegisterCode("GrpcTestNotFound", codes.NotFound)
+
+	test := []struct {
+		Err     error
+		Code    codes.Code
+		Message string
+	}{
+		{nil, codes.OK, ""},
+		{goexer.New("secret"), codes.Unknown, "Unknown"},
+		{goexer.New("secret", goexer.ErrorOpts{Name: "GrpcTestNotFound", PublicMessage: "User not found"}), codes.NotFound, "User not found"},
+		{goexer.Wrap(goexer.New("secret").SetPublic("Bad", "id"), "outer", goexer.ErrorOpts{Name: "GrpcTestNotFound"}), codes.Unknown, "Bad: id"},
+		{goexer.Wrap(context.DeadlineExceeded, "secret"), codes.DeadlineExceeded, "DeadlineExceeded"},
+	}
+
+	for _, tt := range test {
+		st := grpcerr.PublicStatus(tt.Err)
+		if st.Code() != tt.Code || st.Message() != tt.Message {
+			t.Errorf("%v: want %s '%s', got %s '%s'", tt.Err, tt.Code, tt.Message, st.Code(), st.Message())
+		}
+	}
+}
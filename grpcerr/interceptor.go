@@ -0,0 +1,99 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tolyar/goexer"
+	"google.golang.org/grpc"
+)
+
+// Options for server interceptors. By default only public parts of errors are sent (see PublicStatus()).
+type ServerOpts struct {
+	Debug bool // Send ToStatus() with internal messages, locations and container fields. Only for trusted clients.
+}
+
+// Return options from args.
+func serverOpts(args []ServerOpts) ServerOpts {
+	if len(args) > 1 {
+		goexer.New("Only one or zero ServerOpts could be passed to interceptor").LogFatal()
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	return ServerOpts{}
+}
+
+// Convert goexer error returned by handler (or wrapped by other error, e.g. by fmt.Errorf()) to status error.
+// Other errors are returned as is.
+func (o ServerOpts) convert(err error) error {
+	var e *goexer.Error
+	if err == nil || !errors.As(err, &e) {
+		return err
+	}
+
+	if o.Debug {
+		return ToStatus(e).Err()
+	}
+
+	return PublicStatus(e).Err()
+}
+
+// UnaryServerInterceptor - convert goexer errors returned by handlers to gRPC statuses. See ServerOpts.
+func UnaryServerInterceptor(args ...ServerOpts) grpc.UnaryServerInterceptor {
+	opts := serverOpts(args)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		return resp, opts.convert(err)
+	}
+}
+
+// StreamServerInterceptor - convert goexer errors returned by stream handlers to gRPC statuses. See ServerOpts.
+func StreamServerInterceptor(args ...ServerOpts) grpc.StreamServerInterceptor {
+	opts := serverOpts(args)
+
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return opts.convert(handler(srv, ss))
+	}
+}
+
+// UnaryClientInterceptor - convert status errors to *goexer.Error. See FromStatus().
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor - convert status errors of stream creation, SendMsg() and RecvMsg() to *goexer.Error.
+// io.EOF is returned as is.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+// Client stream with converted errors.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return FromError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return FromError(s.ClientStream.RecvMsg(m))
+}
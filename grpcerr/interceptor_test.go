@@ -0,0 +1,110 @@
+package grpcerr_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/Tolyar/goexer/grpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (healthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	err := goexer.Wrap(goexer.New("db is down", goexer.ErrorOpts{PublicMessage: "Try later"}), "check "+req.GetService())
+	if req.GetService() == "wrapped" {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+
+	return nil, err
+}
+
+func (healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, _ grpc_health_v1.Health_WatchServer) error {
+	return goexer.New("watch " + req.GetService())
+}
+
+// Start server with interceptors on bufconn and return client.
+func startServer(t *testing.T, opts grpcerr.ServerOpts) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcerr.UnaryServerInterceptor(opts)),
+		grpc.StreamInterceptor(grpcerr.StreamServerInterceptor(opts)),
+	)
+	grpc_health_v1.RegisterHealthServer(srv, healthServer{})
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcerr.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcerr.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	client := startServer(t, grpcerr.ServerOpts{Debug: true})
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	if !goexer.IsGoexerError(err) {
+		t.Fatalf("Want *goexer.Error, got %T: %v", err, err)
+	}
+
+	e := goexer.ToError(err)
+	if e.Message != "check users" || e.Previous == nil || e.Previous.Message != "db is down" {
+		t.Errorf("Want restored stack, got %s", e.StackString())
+	}
+	if e.Function != "github.com/Tolyar/goexer/grpcerr_test.healthServer.Check" {
+		t.Errorf("Want server location, got %s", e.Function)
+	}
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stream.Recv()
+	if !goexer.IsGoexerError(err) || goexer.ToError(err).Message != "watch users" {
+		t.Errorf("Want *goexer.Error 'watch users', got %T: %v", err, err)
+	}
+}
+
+func TestPublicInterceptor(t *testing.T) {
+	t.Parallel()
+
+	client := startServer(t, grpcerr.ServerOpts{})
+
+	for _, service := range []string{"users", "wrapped"} {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+
+		e := goexer.ToError(err)
+		if e.Message != "Try later" || e.Previous != nil {
+			t.Errorf("%s: want only public message, got %s", service, e.StackString())
+		}
+		if st, _ := status.FromError(e.Original); st.Code() != codes.Unknown || strings.Contains(st.Message(), ".go") {
+			t.Errorf("%s: want Unknown without internal details, got %s '%s'", service, st.Code(), st.Message())
+		}
+	}
+}
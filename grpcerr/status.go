@@ -0,0 +1,172 @@
+package grpcerr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Tolyar/goexer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Domain - domain of ErrorInfo details created by ToStatus(). Details with other domains are ignored by FromStatus().
+var Domain = "goexer"
+
+// ToStatus - convert err to gRPC status. Code is mapped from kind (see Code()), message is the message of outer error.
+// Each error of stack is encoded as pair of details from outer to inner error:
+// ErrorInfo (reason - name, metadata - container fields) and DebugInfo (detail - message, stack entries - location).
+// Joined errors are not encoded. Non goexer errors are converted with status.FromContextError().
+//
+// Details contain internal messages and locations. Use PublicStatus() for untrusted clients.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if !goexer.IsGoexerError(err) {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+
+		return status.FromContextError(err)
+	}
+
+	e := goexer.ToError(err)
+	st := status.New(Code(err), e.Message)
+
+	details := make([]proto.Message, 0, 2)
+	for l := e; l != nil; l = l.Previous {
+		details = append(details, errorInfo(l), &errdetails.DebugInfo{
+			StackEntries: []string{fmt.Sprintf("%s %s:%d", l.Function, l.File, l.Line)},
+			Detail:       l.Message,
+		})
+	}
+
+	return withDetails(st, details)
+}
+
+// Return copy of st with details. Returns st if details can't be encoded.
+func withDetails(st *status.Status, details []proto.Message) *status.Status {
+	p := st.Proto()
+
+	for _, d := range details {
+		a, err := anypb.New(d)
+		if err != nil {
+			return st
+		}
+		p.Details = append(p.Details, a)
+	}
+
+	return status.FromProto(p)
+}
+
+// Return ErrorInfo for one error of stack.
+func errorInfo(e *goexer.Error) *errdetails.ErrorInfo {
+	info := &errdetails.ErrorInfo{Reason: e.Name, Domain: Domain}
+
+	if e.Container != nil && e.Container.Size() > 0 {
+		info.Metadata = make(map[string]string, e.Container.Size())
+		for _, k := range e.Container.Keys() {
+			info.Metadata[k] = fmt.Sprintf("%v", e.Get(k))
+		}
+	}
+
+	return info
+}
+
+// FromStatus - convert gRPC status to *Error. Stack encoded by ToStatus() is restored, container values are strings.
+// Status without goexer details becomes single error with kind registered for code (see RegisterCode()).
+// Each error keeps status as Original, so Code() returns code of status. Returns nil for codes.OK.
+func FromStatus(st *status.Status) *goexer.Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var (
+		infos  []*errdetails.ErrorInfo
+		debugs []*errdetails.DebugInfo
+	)
+
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == Domain {
+				infos = append(infos, d)
+			}
+		case *errdetails.DebugInfo:
+			debugs = append(debugs, d)
+		}
+	}
+
+	if len(infos) == 0 || len(infos) != len(debugs) {
+		name, ok := codeKinds[st.Code()]
+		if !ok {
+			name = goexer.BaseErrorName
+		}
+		e := goexer.New(st.Message(), goexer.ErrorOpts{Name: name, Depth: 1})
+		e.Original = st.Err()
+
+		return e
+	}
+
+	var e *goexer.Error
+
+	for i := len(infos) - 1; i >= 0; i-- {
+		c := goexer.NewContainer()
+		for k, v := range infos[i].GetMetadata() {
+			c.Set(k, v)
+		}
+
+		l := goexer.New(debugs[i].GetDetail(), goexer.ErrorOpts{Name: infos[i].GetReason(), Container: c, Depth: 1})
+		if entries := debugs[i].GetStackEntries(); len(entries) > 0 {
+			setLocation(l, entries[0])
+		}
+
+		l.Original = st.Err()
+		l.Previous = e
+		e = l
+	}
+
+	return e
+}
+
+// FromError - convert error with gRPC status to *Error (see FromStatus()). Other errors are returned as is.
+func FromError(err error) error {
+	if err == nil || goexer.IsGoexerError(err) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	if e := FromStatus(st); e != nil {
+		return e
+	}
+
+	return nil
+}
+
+// Set location of e from stack entry "function file:line". Entry in other format is ignored.
+func setLocation(e *goexer.Error, entry string) {
+	function, location, ok := strings.Cut(entry, " ")
+	if !ok {
+		return
+	}
+
+	i := strings.LastIndex(location, ":")
+	if i < 0 {
+		return
+	}
+
+	line, err := strconv.ParseUint(location[i+1:], 10, 64)
+	if err != nil {
+		return
+	}
+
+	e.Function, e.File, e.Line = function, location[:i], uint(line)
+}
@@ -0,0 +1,81 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/Tolyar/goexer/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//nolint:paralleltest
+func TestStatusRoundTrip(t *testing.T) {
+	grpcerr.RegisterCode("GrpcTestConflict", codes.AlreadyExists)
+
+	inner := goexer.New("duplicate key", goexer.ErrorOpts{
+		Name:      "GrpcTestConflict",
+		Container: goexer.NewContainer().Set("user_id", 42),
+	})
+	err := goexer.Wrap(inner, "can't create user")
+
+	st := grpcerr.ToStatus(err)
+	if st.Code() != codes.AlreadyExists || st.Message() != "can't create user" {
+		t.Fatalf("Want AlreadyExists 'can't create user', got %s '%s'", st.Code(), st.Message())
+	}
+
+	got := grpcerr.FromStatus(st)
+	if got == nil || got.Previous == nil || got.Previous.Previous != nil {
+		t.Fatalf("Want stack of 2 errors, got %+v", got)
+	}
+
+	for _, tt := range []struct{ Got, Want *goexer.Error }{{got, err}, {got.Previous, inner}} {
+		if tt.Got.Name != tt.Want.Name || tt.Got.Message != tt.Want.Message {
+			t.Errorf("Want %s '%s', got %s '%s'", tt.Want.Name, tt.Want.Message, tt.Got.Name, tt.Got.Message)
+		}
+		if tt.Got.Function != tt.Want.Function || tt.Got.File != tt.Want.File || tt.Got.Line != tt.Want.Line {
+			t.Errorf("Want location %s %s:%d, got %s %s:%d",
+				tt.Want.Function, tt.Want.File, tt.Want.Line, tt.Got.Function, tt.Got.File, tt.Got.Line)
+		}
+	}
+
+	if got.Previous.Get("user_id") != "42" {
+		t.Errorf("Want field user_id '42', got %v", got.Previous.Get("user_id"))
+	}
+	if grpcerr.Code(got) != codes.AlreadyExists {
+		t.Errorf("Want AlreadyExists, got %s", grpcerr.Code(got))
+	}
+	if !errors.Is(got, inner) {
+		t.Errorf("Want restored error to be %s", inner.Name)
+	}
+}
+
+func TestFromStatusPlain(t *testing.T) {
+	t.Parallel()
+
+	e := grpcerr.FromStatus(status.New(codes.DeadlineExceeded, "too slow"))
+	if e.Name != goexer.DeadlineExceededErrorName || e.Message != "too slow" {
+		t.Errorf("Want DeadlineExceeded 'too slow', got %s '%s'", e.Name, e.Message)
+	}
+
+	e = grpcerr.FromStatus(status.New(codes.Unavailable, "down"))
+	if e.Name != goexer.BaseErrorName || grpcerr.Code(e) != codes.Unavailable {
+		t.Errorf("Want %s with code Unavailable, got %s %s", goexer.BaseErrorName, e.Name, grpcerr.Code(e))
+	}
+
+	if grpcerr.FromStatus(status.New(codes.OK, "")) != nil {
+		t.Errorf("Want nil for OK status")
+	}
+
+	if st := grpcerr.ToStatus(context.Canceled); st.Code() != codes.Canceled {
+		t.Errorf("Want Canceled, got %s", st.Code())
+	}
+
+	//nolint:goerr113
+	plain := errors.New("plain")
+	if grpcerr.FromError(plain) != plain {
+		t.Errorf("Want non status error as is")
+	}
+}
@@ -1,6 +1,7 @@
 package goexer
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -18,20 +19,59 @@ func newError(depth int, msg string, op ErrorOpts) *Error {
 	err := Error{}
 	opts := DefaultErrorOpts
 
-	switch {
-	case op.Name != "":
+	if op.Name != "" {
 		opts.Name = op.Name
-	case op.Container != nil:
+	}
+	if op.Container != nil {
 		opts.Container = op.Container
-	case op.ShowContainerItems != nil:
+	}
+	if op.ShowContainerItems != nil {
 		opts.ShowContainerItems = op.ShowContainerItems
-	case op.ShowContainerSize != nil:
+	}
+	if op.ShowContainerSize != nil {
 		opts.ShowContainerSize = op.ShowContainerSize
-	case op.ShowContainerAsZKeys != nil:
+	}
+	if op.ShowContainerAsZKeys != nil {
 		opts.ShowContainerAsZKeys = op.ShowContainerAsZKeys
 	}
+	if op.CaptureGoroutine != nil {
+		opts.CaptureGoroutine = op.CaptureGoroutine
+	}
+	if op.Context != nil {
+		opts.Context = op.Context
+	}
+	if op.Retryable != nil {
+		opts.Retryable = op.Retryable
+	}
+	if op.RetryAfter != 0 {
+		opts.RetryAfter = op.RetryAfter
+	}
+	if op.Severity != SeverityDefault {
+		opts.Severity = op.Severity
+	}
+	if op.AddTraceToError != nil {
+		opts.AddTraceToError = op.AddTraceToError
+	}
+	if op.Layout != nil {
+		opts.Layout = op.Layout
+	}
+	if op.CaptureFrames != 0 {
+		opts.CaptureFrames = op.CaptureFrames
+	}
+	if op.PublicMessage != "" {
+		opts.PublicMessage = op.PublicMessage
+	}
+	if op.PublicDetail != "" {
+		opts.PublicDetail = op.PublicDetail
+	}
+	if op.Hints != nil {
+		opts.Hints = op.Hints
+	}
 
 	err.setLocation(depth + opts.Depth) // This error.
+	if opts.CaptureFrames > 0 {
+		err.setFrames(depth+opts.Depth, opts.CaptureFrames)
+	}
 	if opts.Container == nil {
 		err.Container = NewContainer()
 	} else {
@@ -47,6 +87,23 @@ func newError(depth int, msg string, op ErrorOpts) *Error {
 	if opts.ShowContainerSize != nil {
 		err.ShowContainerSize = *opts.ShowContainerSize
 	}
+	if opts.CaptureGoroutine != nil && *opts.CaptureGoroutine {
+		err.setGoroutine(opts.Context)
+	}
+	if opts.Retryable != nil {
+		err.Retryable = *opts.Retryable
+		err.NotRetryable = !*opts.Retryable
+	}
+	err.RetryAfter = opts.RetryAfter
+	err.Severity = opts.Severity
+	if opts.AddTraceToError != nil {
+		err.AddTraceToError = *opts.AddTraceToError
+	}
+	err.Layout = opts.Layout
+	err.PublicMessage = opts.PublicMessage
+	err.PublicDetail = opts.PublicDetail
+	err.Hints = append([]Hint(nil), opts.Hints...)
+	err.applyKind(opts)
 
 	return &err
 }
@@ -101,7 +158,12 @@ func Wrap(prev error, msg string, args ...ErrorOpts) *Error {
 		opts = args[0]
 	}
 
-	err := newError(2, msg, opts) // Current error stack.
+	return wrap(3, prev, msg, opts)
+}
+
+// wrap - common part of Wrap(), Wrapf(), WrapCtx(), ... depth - depth for current error stack.
+func wrap(depth int, prev error, msg string, opts ErrorOpts) *Error {
+	err := newError(depth, msg, opts) // Current error stack.
 
 	if prev == nil {
 		fatal(err, "goexer.Error.Wrap: Incorrect wrap usage. Previous error should not be nil.")
@@ -112,12 +174,34 @@ func Wrap(prev error, msg string, args ...ErrorOpts) *Error {
 	}
 
 	if !IsGoexerError(prev) {
-		errPrev := newError(3, prev.Error(), opts) // Previous error stack.
+		errPrev := newError(depth+1, errorMessage(prev), opts) // Previous error stack.
 		errPrev.Original = prev
 		err.Previous = errPrev
 		err.Original = prev
+
+		if kind := classify(prev, err); kind != "" && (opts.Name == "" || opts.Name == DefaultErrorOpts.Name) {
+			err.Name = kind
+			errPrev.Name = kind
+			err.applyKind(opts)
+			errPrev.applyKind(opts)
+		}
+		// Explicit options have priority over classifiers.
+		if opts.Retryable != nil {
+			err.Retryable = *opts.Retryable
+		}
+		errPrev.Retryable = err.Retryable
 	} else {
 		err.Previous = ToError(prev)
+		err.applyKind(opts)
+		if opts.Retryable == nil {
+			err.Retryable = err.Previous.Retryable
+		}
+		if opts.Severity == SeverityDefault {
+			err.Severity = err.Previous.Severity
+		}
+		if err.RetryAfter == 0 {
+			err.RetryAfter = err.Previous.RetryAfter
+		}
 		if err.Previous.Original == nil {
 			err.Original = err.Previous
 		} else {
@@ -132,7 +216,7 @@ func Wrap(prev error, msg string, args ...ErrorOpts) *Error {
 func ToError(err error) *Error {
 	ee, ok := err.(*Error)
 	if !ok {
-		return newError(3, err.Error(), DefaultErrorOpts) // Previous error stack.
+		return newError(3, errorMessage(err), DefaultErrorOpts) // Previous error stack.
 	}
 
 	return ee
@@ -140,7 +224,7 @@ func ToError(err error) *Error {
 
 // Formatted wrap.
 func Wrapf(prev error, format string, args ...interface{}) *Error {
-	return Wrap(prev, fmt.Sprintf(format, args...))
+	return wrap(3, prev, fmt.Sprintf(format, args...), DefaultErrorOpts)
 }
 
 func Cause(err error) error {
@@ -151,6 +235,21 @@ func Cause(err error) error {
 	return err
 }
 
+// RestoreOriginal - set Original of decoded error e from message of encoded original error. Used by decoders
+// (goexerpb, goexermsgpack). original, prevOriginal - messages of original errors of e and e.Previous,
+// e.Previous should be already decoded.
+func RestoreOriginal(e *Error, original, prevOriginal string) {
+	switch {
+	case e.Previous != nil && e.Previous.Original != nil && original == prevOriginal:
+		e.Original = e.Previous.Original // The same original error as created by Wrap().
+	case original != "":
+		//nolint:goerr113
+		e.Original = errors.New(original)
+	case e.Previous != nil:
+		e.Original = e.Previous
+	}
+}
+
 // SetOpts - set DefaultOptions.
 func SetDefaultOpts(opts ErrorOpts) {
 	DefaultErrorOpts = opts
@@ -166,7 +265,7 @@ func SetBLog(log *log.Logger) {
 	bLog = log
 }
 
-// Check error and log fatal message if not nil.
+// Check error and log fatal message if not nil. Exit code is chosen by logger, use Main() for exit codes of kinds.
 func CheckErr(err error) {
 	if err != nil {
 		ToError(err).LogFatal()
@@ -0,0 +1,130 @@
+package goexer
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Hint - remediation suggestion for users, e.g. "Check that config file exists".
+type Hint struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"` // Link to documentation. Optional.
+}
+
+// String representation of hint, e.g. "Run migrations (https://docs.example.com/migrations)".
+func (h Hint) String() string {
+	if h.URL == "" {
+		return h.Text
+	}
+
+	return h.Text + " (" + h.URL + ")"
+}
+
+// MarshalZerologObject - implements zerolog.LogObjectMarshaler.
+func (h Hint) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("text", h.Text)
+	if h.URL != "" {
+		event.Str("url", h.URL)
+	}
+}
+
+// List of hints for zerolog array field.
+type hintArray []Hint
+
+// MarshalZerologArray - implements zerolog.LogArrayMarshaler.
+func (a hintArray) MarshalZerologArray(arr *zerolog.Array) {
+	for _, h := range a {
+		arr.Object(h)
+	}
+}
+
+// AddHint - add hint to error. url could be empty. Returns e for chaining.
+func (e *Error) AddHint(text, url string) *Error {
+	e.Hints = append(e.Hints, Hint{Text: text, URL: url})
+
+	return e
+}
+
+// AllHints - return hints of the whole chain: own hints of each error and hints of its kind (see RegisterKind()),
+// from outer to inner error, joined errors included. Duplicates are removed.
+func (e *Error) AllHints() []Hint {
+	hints := []Hint{}
+	seen := map[Hint]bool{}
+
+	e.collectHints(&hints, seen)
+
+	return hints
+}
+
+// Return hints of err. See (*Error).AllHints(). Returns nil for non goexer errors.
+func Hints(err error) []Hint {
+	if err == nil || !IsGoexerError(err) {
+		return nil
+	}
+
+	return ToError(err).AllHints()
+}
+
+// Add hints of chain to hints. Hints from seen are skipped.
+func (e *Error) collectHints(hints *[]Hint, seen map[Hint]bool) {
+	add := func(list []Hint) {
+		for _, h := range list {
+			if !seen[h] {
+				seen[h] = true
+				*hints = append(*hints, h)
+			}
+		}
+	}
+
+	for err := e; err != nil; err = err.Previous {
+		add(err.Hints)
+		if kind, ok := LookupKind(err.Name); ok {
+			add(kind.Hints)
+		}
+
+		for _, j := range err.Joined {
+			j.collectHints(hints, seen)
+		}
+	}
+}
+
+// Return hints of this layer and its kind without duplicates. Used by single layer renderers.
+func (e *Error) ownHints() []Hint {
+	hints := []Hint{}
+	seen := map[Hint]bool{}
+
+	list := e.Hints
+	if kind, ok := LookupKind(e.Name); ok {
+		list = append(append([]Hint(nil), list...), kind.Hints...)
+	}
+
+	for _, h := range list {
+		if !seen[h] {
+			seen[h] = true
+			hints = append(hints, h)
+		}
+	}
+
+	return hints
+}
+
+// Return hints block for multi line style. paint - "paint" function of layout templates.
+func hintsString(hints []Hint, paint func(string, any) string) string {
+	if len(hints) == 0 {
+		return ""
+	}
+
+	b := strings.Builder{}
+	b.WriteString("Hints:\n")
+
+	for _, h := range hints {
+		b.WriteString("\t- " + paint("hint", h.Text))
+		if h.URL != "" {
+			b.WriteString(" (" + paint("url", h.URL) + ")")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package goexer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/rs/zerolog"
+)
+
+//nolint:paralleltest
+func TestAllHints(t *testing.T) {
+	goexer.RegisterKind("HintTestConfig", goexer.KindOpts{
+		Hints: []goexer.Hint{{Text: "Check config file", URL: "https://example.com/config"}},
+	})
+
+	inner := goexer.New("can't open config", goexer.ErrorOpts{
+		Name:  "HintTestConfig",
+		Hints: []goexer.Hint{{Text: "Run with --config"}},
+	})
+	err := goexer.Wrap(inner, "can't start").AddHint("Run with --config", "").AddHint("Reinstall", "")
+
+	want := []goexer.Hint{
+		{Text: "Run with --config"},
+		{Text: "Reinstall"},
+		{Text: "Check config file", URL: "https://example.com/config"},
+	}
+	if got := err.AllHints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want %v, got %v", want, got)
+	}
+
+	joined := goexer.Join(goexer.New("a").AddHint("Retry a", ""), goexer.New("b").AddHint("Retry b", ""))
+	if got := goexer.Hints(joined); len(got) != 2 || got[0].Text != "Retry a" || got[1].Text != "Retry b" {
+		t.Errorf("Want hints of joined errors, got %v", got)
+	}
+
+	if got := goexer.Hints(nil); got != nil {
+		t.Errorf("Want nil, got %v", got)
+	}
+}
+
+func TestHintsRendering(t *testing.T) {
+	t.Parallel()
+
+	inner := goexer.New("inner").AddHint("Check permissions", "https://example.com/perm")
+	err := goexer.Wrap(inner, "outer").AddHint("Retry later", "")
+
+	want := "Hints:\n\t- Retry later\n\t- Check permissions (https://example.com/perm)\n"
+
+	s := err.StackString()
+	if !strings.HasSuffix(s, want) {
+		t.Errorf("Want stack ending with '%s', got '%s'", want, s)
+	}
+	if strings.Count(s, "Hints:") != 1 {
+		t.Errorf("Want one hints block, got '%s'", s)
+	}
+
+	if s := err.MultiLinePrettyError(); !strings.HasSuffix(s, "Hints:\n\t- Retry later\n") {
+		t.Errorf("Want multi line error with own hints only, got '%s'", s)
+	}
+
+	if s := goexer.New("no hints").StackString(); strings.Contains(s, "Hints:") {
+		t.Errorf("Want no hints block, got '%s'", s)
+	}
+
+	if s := err.ColorStackString(); !strings.Contains(s, goexer.ColorScheme["hint"]+"Retry later") {
+		t.Errorf("Want colored hint, got '%q'", s)
+	}
+}
+
+//nolint:paralleltest
+func TestLogHints(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zlog := zerolog.New(buf)
+	goexer.SetZLog(&zlog)
+	defer goexer.SetZLog(nil)
+
+	goexer.New("test").AddHint("Check disk", "https://example.com/disk").AddHint("Free space", "").LogError()
+
+	rec := struct {
+		Hints []goexer.Hint `json:"hints"`
+	}{}
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+		t.Fatalf("Can't parse log record '%s': %v", buf.String(), e)
+	}
+
+	want := []goexer.Hint{{Text: "Check disk", URL: "https://example.com/disk"}, {Text: "Free space"}}
+	if !reflect.DeepEqual(rec.Hints, want) {
+		t.Errorf("Want %v, got %v", want, rec.Hints)
+	}
+}
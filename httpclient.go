@@ -0,0 +1,176 @@
+package goexer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPErrorName - kind of non-2xx responses without more specific kind. See Transport.
+const HTTPErrorName = "HTTPError"
+
+// Query parameters redacted by Transport by default.
+var DefaultRedactedParams = []string{
+	"access_token", "api_key", "apikey", "auth", "key", "password", "secret", "sig", "signature", "token",
+}
+
+// Kinds for response status codes. Other error statuses have HTTPErrorName kind.
+var httpStatusKinds = map[int]string{
+	http.StatusNotFound:       NotFoundErrorName,
+	http.StatusForbidden:      PermissionDeniedErrorName,
+	http.StatusRequestTimeout: TimeoutErrorName,
+	http.StatusGatewayTimeout: TimeoutErrorName,
+}
+
+// Retryable response status codes.
+var retryableStatuses = map[int]bool{
+	http.StatusRequestTimeout:     true,
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// Transport - http.RoundTripper which turns transport failures and error responses into *Error.
+// Container has "method", "url" (with redacted query), "status" and "body" (beginning of response body) fields.
+// Responses with Retry-After header and statuses 408, 429, 502, 503, 504 are retryable (see IsRetryable(), Retry()).
+//
+// http.Client wraps errors of transport into *url.Error, Wrap() of such error inherits kind and fields.
+// Raw URL in the message of *url.Error is replaced with redacted one.
+//
+//	client := &http.Client{Transport: &goexer.Transport{}}
+type Transport struct {
+	Base         http.RoundTripper     // http.DefaultTransport if nil.
+	RedactParams []string              // Query parameters with redacted values. DefaultRedactedParams if nil.
+	BodySnippet  int                   // Max length of response body in "body" field. 512 if 0, negative - do not read body.
+	IsError      func(status int) bool // Check if response is error. By default status >= 400 (redirects are passed to client).
+}
+
+// RoundTrip - implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c := NewContainer().Set("method", req.Method).Set("url", t.redact(req.URL))
+	msg := req.Method + " " + t.redact(req.URL)
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, Wrap(err, msg, ErrorOpts{Container: c})
+	}
+
+	isError := t.IsError
+	if isError == nil {
+		isError = func(status int) bool { return status >= http.StatusBadRequest }
+	}
+	if !isError(resp.StatusCode) {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	c.Set("status", resp.StatusCode)
+	if body := t.readBody(resp.Body); body != "" {
+		c.Set("body", body)
+	}
+
+	kind, ok := httpStatusKinds[resp.StatusCode]
+	if !ok {
+		kind = HTTPErrorName
+	}
+
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+	retryable := retryableStatuses[resp.StatusCode] || retryAfter > 0
+
+	return nil, New(msg+": "+resp.Status, ErrorOpts{
+		Name:       kind,
+		Container:  c,
+		Retryable:  &retryable,
+		RetryAfter: retryAfter,
+	})
+}
+
+// Return URL with redacted password and values of query parameters from RedactParams.
+func (t *Transport) redact(u *url.URL) string {
+	params := t.RedactParams
+	if params == nil {
+		params = DefaultRedactedParams
+	}
+
+	ru := *u
+	if ru.RawQuery != "" {
+		q := ru.Query()
+		for k := range q {
+			for _, p := range params {
+				if strings.EqualFold(k, p) {
+					q.Set(k, "REDACTED")
+				}
+			}
+		}
+		ru.RawQuery = q.Encode()
+	}
+
+	return ru.Redacted()
+}
+
+// Return message of non goexer error for Wrap(), Join(), ... http.Client wraps Transport errors into *url.Error with
+// raw URL in the message, so it's replaced with redacted URL from "url" field of Transport error.
+func errorMessage(err error) string {
+	msg := err.Error()
+
+	var (
+		ue *url.Error
+		te *Error
+	)
+	if !errors.As(err, &ue) || !errors.As(ue.Err, &te) {
+		return msg
+	}
+
+	if u, ok := te.GetE("url"); ok {
+		if s, ok := u.(string); ok && s != ue.URL {
+			msg = strings.ReplaceAll(msg, strconv.Quote(ue.URL), strconv.Quote(s))
+		}
+	}
+
+	return msg
+}
+
+// Return beginning of response body.
+func (t *Transport) readBody(body io.Reader) string {
+	limit := t.BodySnippet
+	if limit < 0 {
+		return ""
+	}
+	if limit == 0 {
+		limit = 512
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(body, int64(limit)))
+
+	return strings.TrimSpace(string(b))
+}
+
+// Parse Retry-After header: delay in seconds or HTTP date. Returns 0 if header is empty or invalid.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
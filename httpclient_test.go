@@ -0,0 +1,121 @@
+package goexer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("ok"))
+		case "/missing":
+			http.Error(w, "no such user", http.StatusNotFound)
+		case "/busy":
+			w.Header().Set("Retry-After", "7")
+			http.Error(w, strings.Repeat("x", 100), http.StatusServiceUnavailable)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &goexer.Transport{BodySnippet: 10}}
+
+	resp, err := client.Get(srv.URL + "/redirect")
+	if err != nil {
+		t.Fatalf("Want redirect to /ok, got %v", err)
+	}
+	resp.Body.Close()
+
+	test := []struct {
+		Path      string
+		Kind      string
+		Status    int
+		Body      string
+		Retryable bool
+		URL       string
+	}{
+		{"/missing?token=secret&id=1", goexer.NotFoundErrorName, 404, "no such us", false, srv.URL + "/missing?id=1&token=REDACTED"},
+		{"/busy", goexer.HTTPErrorName, 503, "xxxxxxxxxx", true, srv.URL + "/busy"},
+		{"/other", goexer.HTTPErrorName, 400, "bad reques", false, srv.URL + "/other"},
+	}
+
+	for _, tt := range test {
+		_, err := client.Get(srv.URL + tt.Path) //nolint:bodyclose
+		if err == nil {
+			t.Fatalf("%s: want error", tt.Path)
+		}
+
+		e := goexer.Wrap(err, "call api")
+		if e.Name != tt.Kind || e.Retryable != tt.Retryable || goexer.IsRetryable(err) != tt.Retryable {
+			t.Errorf("%s: want %s (retryable %v), got %s (retryable %v)", tt.Path, tt.Kind, tt.Retryable, e.Name, e.Retryable)
+		}
+		if e.Get("status") != tt.Status || e.Get("body") != tt.Body || e.Get("method") != http.MethodGet || e.Get("url") != tt.URL {
+			t.Errorf("%s: unexpected fields %v", tt.Path, e.Container.Marshaled())
+		}
+		var te *goexer.Error
+		if !errors.As(err, &te) || strings.Contains(te.Error(), "secret") {
+			t.Errorf("%s: want *goexer.Error with redacted URL, got %v", tt.Path, te)
+		}
+		if s := e.StackString(); strings.Contains(s, "secret") || !strings.Contains(s, tt.URL) {
+			t.Errorf("%s: want stack with redacted URL, got '%s'", tt.Path, s)
+		}
+		if s := e.TreeString(goexer.TreeOpts{ShowOriginal: true}); strings.Contains(s, "secret") {
+			t.Errorf("%s: want tree with redacted URL, got '%s'", tt.Path, s)
+		}
+		if b, jErr := json.Marshal(e); jErr != nil || strings.Contains(string(b), "secret") {
+			t.Errorf("%s: want JSON with redacted URL, got '%s' (%v)", tt.Path, b, jErr)
+		}
+	}
+
+	_, err = client.Get(srv.URL + "/busy") //nolint:bodyclose
+	if ra := goexer.GetRetryAfter(goexer.Wrap(err, "call")); ra != 7*time.Second {
+		t.Errorf("Want Retry-After 7s, got %s", ra)
+	}
+}
+
+func TestTransportFailure(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	addr := srv.URL
+	srv.Close()
+
+	client := &http.Client{Transport: &goexer.Transport{}}
+
+	_, err := client.Get(addr + "/?api_key=secret") //nolint:bodyclose
+	e := goexer.Wrap(err, "call api")
+
+	if s := e.StackString(); strings.Contains(s, "secret") {
+		t.Errorf("Want stack with redacted URL, got '%s'", s)
+	}
+	if e.Name != goexer.ConnectionRefusedErrorName || !e.Retryable {
+		t.Errorf("Want retryable ConnectionRefused, got %s (retryable %v)", e.Name, e.Retryable)
+	}
+	if e.Get("url") != addr+"/?api_key=REDACTED" || e.Get("method") != http.MethodGet {
+		t.Errorf("Unexpected fields %v", e.Container.Marshaled())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, addr, nil)
+	_, err = client.Do(req) //nolint:bodyclose
+	if e := goexer.Wrap(err, "call api"); e.Name != goexer.CanceledErrorName || goexer.IsRetryable(err) {
+		t.Errorf("Want not retryable Canceled, got %v", err)
+	}
+}
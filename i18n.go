@@ -0,0 +1,181 @@
+package goexer
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// MissingParam - behavior of Localize() when translation has parameter without value.
+type MissingParam int8
+
+const (
+	MissingParamFallback MissingParam = iota // Use untranslated Message.
+	MissingParamKeep                         // Keep placeholder, e.g. "{user_id}".
+	MissingParamEmpty                        // Replace placeholder with empty string.
+)
+
+// Catalog - translations of error messages. Key of translation is MessageTemplate (see NewT()) or kind (Error.Name).
+// Translations are templates with {param} placeholders, values are taken from container fields of errors in stack.
+type Catalog struct {
+	DefaultLocale string       // Used if there is no translation for requested locale.
+	MissingParam  MissingParam // Behavior for parameters without values.
+	messages      map[string]map[string]string
+}
+
+// DefaultCatalog - catalog used by Localize().
+var DefaultCatalog = NewCatalog("en")
+
+// NewCatalog - create empty Catalog.
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		DefaultLocale: defaultLocale,
+		messages:      make(map[string]map[string]string),
+	}
+}
+
+// Add - add translation for key (kind or message template) and locale.
+func (c *Catalog) Add(locale, key, tmpl string) *Catalog {
+	locale = normalizeLocale(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string)
+	}
+	c.messages[locale][key] = tmpl
+
+	return c
+}
+
+// LoadJSON - add translations for locale from JSON object, e.g. {"NotFound": "user {user_id} not found"}.
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	messages := map[string]string{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return WrapT(err, "Can't parse translations for locale {locale}", locale)
+	}
+
+	for key, tmpl := range messages {
+		c.Add(locale, key, tmpl)
+	}
+
+	return nil
+}
+
+// LoadFS - load translations from JSON files in dir of fsys (e.g. embed.FS). Name of file is locale, e.g. "de.json".
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return Wrap(err, "Can't list translations")
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return WrapT(err, "Can't read translations from {file}", file)
+		}
+
+		if err := c.LoadJSON(strings.TrimSuffix(path.Base(file), ".json"), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Localize - return message of err translated to locale with DefaultCatalog.
+func Localize(err error, locale string) string {
+	return DefaultCatalog.Localize(err, locale)
+}
+
+// Localize - return message of err translated to locale. Locales are tried in order: locale ("pt-BR"),
+// its language ("pt"), DefaultLocale. Untranslated Message is returned if there is no translation.
+func (c *Catalog) Localize(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+	if !IsGoexerError(err) {
+		return err.Error()
+	}
+
+	e := ToError(err)
+
+	tmpl, ok := c.lookup(e, locale)
+	if !ok {
+		return e.Message
+	}
+
+	missing := false
+	msg := placeholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		if v, ok := e.lookupField(p[1 : len(p)-1]); ok {
+			return renderValue(v)
+		}
+
+		missing = true
+		if c.MissingParam == MissingParamEmpty {
+			return ""
+		}
+
+		return p
+	})
+
+	if missing && c.MissingParam == MissingParamFallback {
+		return e.Message
+	}
+
+	return msg
+}
+
+// Return translation for error. MessageTemplate has priority over kind.
+func (c *Catalog) lookup(e *Error, locale string) (string, bool) {
+	keys := []string{e.Name}
+	if e.MessageTemplate != "" {
+		keys = []string{e.MessageTemplate, e.Name}
+	}
+
+	for _, l := range localeChain(locale, c.DefaultLocale) {
+		for _, key := range keys {
+			if tmpl, ok := c.messages[l][key]; ok {
+				return tmpl, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// lookupField - find field in containers of stack, from outer error to inner.
+func (e *Error) lookupField(key string) (any, bool) {
+	for err := e; err != nil; err = err.Previous {
+		if err.Container == nil {
+			continue
+		}
+		if v, ok := err.GetE(key); ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// Return locales for lookup, e.g. ["pt-br", "pt", "en"] for "pt_BR" and default "en".
+func localeChain(locale, defaultLocale string) []string {
+	chain := []string{}
+
+	for _, l := range []string{locale, defaultLocale} {
+		l = normalizeLocale(l)
+		if l == "" {
+			continue
+		}
+
+		chain = append(chain, l)
+		if i := strings.Index(l, "-"); i > 0 {
+			chain = append(chain, l[:i])
+		}
+	}
+
+	return chain
+}
+
+// Return locale in lower case with "-" separator, e.g. "pt-br" for "pt_BR".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
@@ -0,0 +1,99 @@
+package goexer_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestCatalogLocalize(t *testing.T) {
+	t.Parallel()
+
+	c := goexer.NewCatalog("en")
+	fsys := fstest.MapFS{
+		"i18n/en.json":    {Data: []byte(`{"NotFound": "Not found", "user {user_id} not found": "User {user_id} not found"}`)},
+		"i18n/de.json":    {Data: []byte(`{"NotFound": "Nicht gefunden", "user {user_id} not found": "Benutzer {user_id} nicht gefunden"}`)},
+		"i18n/pt-BR.json": {Data: []byte(`{"NotFound": "Não encontrado"}`)},
+		"i18n/README.md":  {Data: []byte(`not a translation`)},
+	}
+	if err := c.LoadFS(fsys, "i18n"); err != nil {
+		t.Fatal(err)
+	}
+
+	notFound := goexer.New("no such user", goexer.ErrorOpts{Name: "NotFound"})
+	userNotFound := goexer.NewT("user {user_id} not found", 42)
+	wrapped := goexer.Wrap(userNotFound, "can't login")
+
+	test := []struct {
+		Err    error
+		Locale string
+		Want   string
+	}{
+		{notFound, "de", "Nicht gefunden"},
+		{notFound, "de_AT", "Nicht gefunden"},
+		{notFound, "pt_br", "Não encontrado"},
+		{notFound, "fr", "Not found"},
+		{userNotFound, "de-DE", "Benutzer 42 nicht gefunden"},
+		{userNotFound, "pt-BR", "User 42 not found"},
+		{wrapped, "de", "can't login"},
+		{goexer.New("other"), "de", "other"},
+		//nolint:goerr113
+		{errors.New("plain"), "de", "plain"},
+	}
+
+	for _, tt := range test {
+		if got := c.Localize(tt.Err, tt.Locale); got != tt.Want {
+			t.Errorf("Localize(%v, %s): want '%s', got '%s'", tt.Err, tt.Locale, tt.Want, got)
+		}
+	}
+}
+
+func TestCatalogMissingParam(t *testing.T) {
+	t.Parallel()
+
+	c := goexer.NewCatalog("en").
+		Add("en", "Conflict", "Order {order_id} of {user_id} already exists")
+
+	inner := goexer.NewT("user {user_id}", 7)
+	err := goexer.Wrap(inner, "conflict")
+	err.Name = "Conflict" // Wrap() keeps kind of previous error.
+
+	// user_id is found in previous error.
+	err.Set("order_id", 1)
+	if got := c.Localize(err, "en"); got != "Order 1 of 7 already exists" {
+		t.Errorf("Want 'Order 1 of 7 already exists', got '%s'", got)
+	}
+
+	err = goexer.New("conflict", goexer.ErrorOpts{Name: "Conflict"})
+	if got := c.Localize(err, "en"); got != "conflict" {
+		t.Errorf("Fallback: want 'conflict', got '%s'", got)
+	}
+
+	c.MissingParam = goexer.MissingParamKeep
+	if got := c.Localize(err, "en"); got != "Order {order_id} of {user_id} already exists" {
+		t.Errorf("Keep: want placeholders, got '%s'", got)
+	}
+
+	c.MissingParam = goexer.MissingParamEmpty
+	if got := c.Localize(err, "en"); got != "Order  of  already exists" {
+		t.Errorf("Empty: want empty values, got '%s'", got)
+	}
+
+	if err := c.LoadJSON("en", []byte("{")); err == nil {
+		t.Error("Want error for incorrect JSON")
+	}
+}
+
+//nolint:paralleltest
+func TestLocalize(t *testing.T) {
+	goexer.DefaultCatalog.Add("ru", "LocalizeTestKind", "Ошибка {code}")
+
+	err := goexer.New("error", goexer.ErrorOpts{Name: "LocalizeTestKind"})
+	err.Set("code", 5)
+
+	if got := goexer.Localize(err, "ru-RU"); got != "Ошибка 5" {
+		t.Errorf("Want 'Ошибка 5', got '%s'", got)
+	}
+}
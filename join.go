@@ -0,0 +1,69 @@
+package goexer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Join - create Error with errs as joined errors (see Error.Joined). nil errors are skipped.
+// Returns nil if there are no errors. Message of Error is messages of errs separated by "; ".
+//
+// Like New() and Wrap(), Join() returns *Error, so nil result is a typed nil. Check it before returning as error:
+//
+//	if err := goexer.Join(errs...); err != nil {
+//		return err
+//	}
+//
+//	return nil
+func Join(errs ...error) *Error {
+	return join(3, errs)
+}
+
+// foreignError - create Error for non goexer error with classification applied (see RegisterClassifier()).
+// depth - depth of the caller of foreignError().
+func foreignError(depth int, err error) *Error {
+	e := newError(depth+1, errorMessage(err), DefaultErrorOpts)
+	e.Original = err
+	if kind := classify(err, e); kind != "" {
+		e.Name = kind
+		e.applyKind(ErrorOpts{})
+	}
+
+	return e
+}
+
+// join - common part of Join() and other joining helpers. depth - depth for current error stack.
+func join(depth int, errs []error) *Error {
+	joined := make([]*Error, 0, len(errs))
+	messages := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var e *Error
+		if IsGoexerError(err) {
+			e = ToError(err)
+		} else {
+			e = foreignError(depth, err)
+		}
+
+		joined = append(joined, e)
+		messages = append(messages, e.Message)
+	}
+
+	if len(joined) == 0 {
+		return nil
+	}
+
+	msg := strings.Join(messages, "; ")
+	if len(joined) > 1 {
+		msg = fmt.Sprintf("%d errors: %s", len(joined), msg)
+	}
+
+	err := newError(depth, msg, DefaultErrorOpts)
+	err.Joined = joined
+
+	return err
+}
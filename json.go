@@ -0,0 +1,54 @@
+package goexer
+
+import "encoding/json"
+
+// JSON representation of Error.
+type errorJSON struct {
+	Name            string    `json:"name"`
+	Message         string    `json:"message"`
+	MessageTemplate string    `json:"message_template,omitempty"`
+	Function        string    `json:"function"`
+	File            string    `json:"file"`
+	Line            uint      `json:"line"`
+	Severity        string    `json:"severity"`
+	Fingerprint     string    `json:"fingerprint"`
+	Retryable       bool      `json:"retryable,omitempty"`
+	Goroutine       uint64    `json:"goroutine,omitempty"`
+	Fields          Marshaled `json:"fields,omitempty"`
+	Frames          []Frame   `json:"frames,omitempty"` // Raw frames, filters are not applied.
+	Hints           []Hint    `json:"hints,omitempty"`  // Own hints of error.
+	Original        string    `json:"original,omitempty"`
+	Previous        *Error    `json:"previous,omitempty"`
+	Joined          []*Error  `json:"joined,omitempty"`
+}
+
+// MarshalJSON - implements json.Marshaler. Previous and joined errors are nested.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	j := errorJSON{
+		Name:            e.Name,
+		Message:         e.Message,
+		MessageTemplate: e.MessageTemplate,
+		Function:        e.Function,
+		File:            e.File,
+		Line:            e.Line,
+		Severity:        e.Severity.String(),
+		Fingerprint:     e.Fingerprint(),
+		Retryable:       e.Retryable,
+		Goroutine:       e.GoroutineID,
+		Frames:          e.Frames,
+		Hints:           e.Hints,
+		Previous:        e.Previous,
+		Joined:          e.Joined,
+	}
+
+	if e.Container != nil && e.Container.Size() > 0 {
+		j.Fields = e.Container.Marshaled()
+	}
+
+	if e.ownOriginal() && !IsGoexerError(e.Original) {
+		j.Original = errorMessage(e.Original)
+	}
+
+	//nolint:wrapcheck
+	return json.Marshal(j)
+}
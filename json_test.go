@@ -0,0 +1,42 @@
+package goexer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	//nolint:goerr113
+	err := goexer.Wrap(errors.New("original"), "current", goexer.ErrorOpts{Name: "NotFound"})
+	err.Set("id", 10)
+
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	j := map[string]any{}
+	if e := json.Unmarshal(data, &j); e != nil {
+		t.Fatal(e)
+	}
+
+	if j["name"] != "NotFound" || j["message"] != "current" || j["severity"] != "error" || j["line"] != float64(err.Line) {
+		t.Errorf("Incorrect JSON: %s", data)
+	}
+	if fields, ok := j["fields"].(map[string]any); !ok || fields["id"] != float64(10) {
+		t.Errorf("Want fields.id=10, got %s", data)
+	}
+	if _, ok := j["original"]; ok {
+		t.Errorf("Original should be only in previous error: %s", data)
+	}
+
+	prev, ok := j["previous"].(map[string]any)
+	if !ok || prev["message"] != "original" || prev["original"] != "original" {
+		t.Errorf("Incorrect previous error: %s", data)
+	}
+}
@@ -0,0 +1,85 @@
+package goexer
+
+import "net/http"
+
+// Options of error kind (Error.Name). See RegisterKind().
+type KindOpts struct {
+	Severity        Severity // Default severity for errors of this kind.
+	AddTraceToError *bool    // Add trace messages to error and fatal messages for errors of this kind.
+	Layout          *Layout  // Templates for text representation of errors of this kind.
+	HTTPStatus      int      // HTTP status code for errors of this kind. See HTTPStatus().
+	Hints           []Hint   // Remediation hints for errors of this kind. See AllHints().
+	ExitCode        int      // Process exit code for errors of this kind. See ExitCode().
+}
+
+var kinds = map[string]KindOpts{
+	CanceledErrorName:         {HTTPStatus: 499, ExitCode: ExitInterrupted}, // Client Closed Request.
+	DeadlineExceededErrorName: {HTTPStatus: http.StatusGatewayTimeout, ExitCode: ExitTempFail},
+
+	NotFoundErrorName:             {HTTPStatus: http.StatusNotFound, ExitCode: ExitNoInput},
+	UniqueViolationErrorName:      {HTTPStatus: http.StatusConflict, ExitCode: ExitDataErr},
+	ForeignKeyViolationErrorName:  {HTTPStatus: http.StatusConflict, ExitCode: ExitDataErr},
+	IntegrityViolationErrorName:   {HTTPStatus: http.StatusConflict, ExitCode: ExitDataErr},
+	NotNullViolationErrorName:     {HTTPStatus: http.StatusBadRequest, ExitCode: ExitDataErr},
+	CheckViolationErrorName:       {HTTPStatus: http.StatusBadRequest, ExitCode: ExitDataErr},
+	DeadlockErrorName:             {HTTPStatus: http.StatusServiceUnavailable, ExitCode: ExitTempFail},
+	SerializationFailureErrorName: {HTTPStatus: http.StatusServiceUnavailable, ExitCode: ExitTempFail},
+
+	PermissionDeniedErrorName:  {HTTPStatus: http.StatusForbidden, ExitCode: ExitNoPerm},
+	AlreadyExistsErrorName:     {HTTPStatus: http.StatusConflict, ExitCode: ExitCantCreat},
+	TimeoutErrorName:           {HTTPStatus: http.StatusGatewayTimeout, ExitCode: ExitTempFail},
+	ConnectionRefusedErrorName: {HTTPStatus: http.StatusBadGateway, ExitCode: ExitUnavailable},
+
+	PanicErrorName: {Severity: SeverityCritical, ExitCode: ExitSoftware},
+}
+
+// RegisterKind - set options for errors of kind name. Options are applied when error is created.
+// Non zero options are merged into options of already registered kind, e.g. adding Hints keeps HTTPStatus and ExitCode
+// of built-in kind. Hints are replaced, not appended.
+// Should be called before errors are created (e.g. in init()).
+func RegisterKind(name string, opts KindOpts) {
+	kind := kinds[name]
+
+	if opts.Severity != SeverityDefault {
+		kind.Severity = opts.Severity
+	}
+	if opts.AddTraceToError != nil {
+		kind.AddTraceToError = opts.AddTraceToError
+	}
+	if opts.Layout != nil {
+		kind.Layout = opts.Layout
+	}
+	if opts.HTTPStatus != 0 {
+		kind.HTTPStatus = opts.HTTPStatus
+	}
+	if opts.Hints != nil {
+		kind.Hints = opts.Hints
+	}
+	if opts.ExitCode != 0 {
+		kind.ExitCode = opts.ExitCode
+	}
+
+	kinds[name] = kind
+}
+
+// LookupKind - return options of kind name and true if kind was registered.
+func LookupKind(name string) (KindOpts, bool) {
+	opts, ok := kinds[name]
+
+	return opts, ok
+}
+
+// applyKind - set options of error kind (see RegisterKind()) which were not set explicitly in opts.
+func (e *Error) applyKind(opts ErrorOpts) {
+	kind := kinds[e.Name]
+
+	if opts.Severity == SeverityDefault {
+		e.Severity = kind.Severity
+		if e.Severity == SeverityDefault {
+			e.Severity = SeverityError
+		}
+	}
+	if opts.AddTraceToError == nil && kind.AddTraceToError != nil {
+		e.AddTraceToError = *kind.AddTraceToError
+	}
+}
@@ -0,0 +1,120 @@
+package goexer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"text/template"
+)
+
+// Layout - templates (text/template) for text representation of Error. Template data is *Error.
+// Templates could use functions from LayoutFuncs. nil template means template from default layout.
+type Layout struct {
+	OneLine   *template.Template // Used by Error(), OneLinePrettyError(), %s and %v formats.
+	MultiLine *template.Template // Used by MultiLinePrettyError(), StackString() and %+v format.
+}
+
+const (
+	// DefaultOneLineTemplate - e.g. "Name: file.go:10 pkg.Func(): 'msg' (cSize: 2) (Fields: a: 1; b: 2;)".
+	DefaultOneLineTemplate = `{{paint "name" .Name}}: {{paint "location" (printf "%s:%d" .ShortFile .Line)}} ` +
+		`{{paint "function" .FunctionName}}(): '{{paint "message" .Message}}'` +
+		`{{if and .ShowContainerSize (not .ShowContainerAsZKeys)}} (cSize: {{.Container.Size}}){{end}}` +
+		`{{if and .ShowContainerItems (not .ShowContainerAsZKeys)}} (Fields:` +
+		`{{range .ShowContainerItems}} {{paint "field" .}}: {{value ($.Get .)}};{{end}}){{end}}`
+	// DefaultMultiLineTemplate - block for one error from stack.
+	DefaultMultiLineTemplate = `{{paint "function" .FunctionName}}(): {{paint "message" .Message}}` + "\n" +
+		`	{{paint "location" (printf "%s:%d" .FilePath .Line)}}` + "\n" +
+		"{{if .ShowContainerSize}}\tContainer size: {{.Container.Size}}\n{{end}}" +
+		`{{if .ShowContainerItems}}	Container fields:{{range .ShowContainerItems}} {{paint "field" .}}: {{value ($.Get .)}};{{end}}` + "\n{{end}}" +
+		"{{if .GoroutineID}}\tGoroutine: {{.GoroutineString}}\n{{end}}" +
+		"{{with .FilteredFrames}}\tFrames:\n{{range .}}\t\t{{.}}\n{{end}}{{end}}"
+)
+
+// LayoutFuncs - functions available in layout templates.
+// "paint" highlights value with style from ColorScheme in colored output and returns plain value otherwise.
+var LayoutFuncs = template.FuncMap{
+	"paint": plainPaint,
+	"base":  filepath.Base,                                      // Base name of file.
+	"value": func(v any) string { return fmt.Sprintf("%v", v) }, // Value in %v format.
+}
+
+var (
+	originalLayout = MustLayout(DefaultOneLineTemplate, DefaultMultiLineTemplate)
+	defaultLayout  *Layout // Set by SetDefaultLayout().
+)
+
+// NewLayout - create Layout from one line and multi line templates. Empty string means template from default layout.
+func NewLayout(oneLine, multiLine string) (*Layout, error) {
+	layout := Layout{}
+
+	if oneLine != "" {
+		t, err := template.New("oneLine").Funcs(LayoutFuncs).Parse(oneLine)
+		if err != nil {
+			return nil, Wrap(err, "Can't parse one line template")
+		}
+		layout.OneLine = t
+	}
+
+	if multiLine != "" {
+		t, err := template.New("multiLine").Funcs(LayoutFuncs).Parse(multiLine)
+		if err != nil {
+			return nil, Wrap(err, "Can't parse multi line template")
+		}
+		layout.MultiLine = t
+	}
+
+	return &layout, nil
+}
+
+// MustLayout - like NewLayout(), but panics on incorrect templates.
+func MustLayout(oneLine, multiLine string) *Layout {
+	layout, err := NewLayout(oneLine, multiLine)
+	if err != nil {
+		panic(err)
+	}
+
+	return layout
+}
+
+// SetDefaultLayout - set layout for errors without own layout. nil restores original layout.
+func SetDefaultLayout(layout *Layout) {
+	defaultLayout = layout
+}
+
+// Return layouts for error in priority order: Error.Layout, layout of kind, default layout, original layout.
+func (e *Error) layouts() []*Layout {
+	layouts := make([]*Layout, 0, 4)
+
+	if e.Layout != nil {
+		layouts = append(layouts, e.Layout)
+	}
+	if kind, ok := LookupKind(e.Name); ok && kind.Layout != nil {
+		layouts = append(layouts, kind.Layout)
+	}
+
+	if defaultLayout != nil {
+		layouts = append(layouts, defaultLayout)
+	}
+
+	return append(layouts, originalLayout)
+}
+
+// render - execute first available template. get - return template from layout.
+// Falls back to next layout if template fails.
+func (e *Error) render(get func(*Layout) *template.Template) string {
+	buf := bytes.Buffer{}
+
+	for _, layout := range e.layouts() {
+		t := get(layout)
+		if t == nil {
+			continue
+		}
+
+		buf.Reset()
+		if err := t.Execute(&buf, e); err == nil {
+			return buf.String()
+		}
+	}
+
+	return buf.String()
+}
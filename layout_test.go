@@ -0,0 +1,78 @@
+package goexer_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestDefaultLayout(t *testing.T) {
+	t.Parallel()
+
+	tr := true
+	c := goexer.NewContainer().Set("a", 1)
+	pc, _, line, _ := runtime.Caller(0)
+	err := goexer.New("msg", goexer.ErrorOpts{Container: c, ShowContainerSize: &tr, ShowContainerItems: []string{"a", "absent"}})
+	fn := runtime.FuncForPC(pc).Name()
+
+	want := fmt.Sprintf("BaseError: layout_test.go:%d %s(): 'msg' (cSize: 1) (Fields: a: 1; absent: <nil>;)", line+1, fn)
+	if err.Error() != want {
+		t.Errorf("Want '%s', got '%s'", want, err.Error())
+	}
+
+	want = fmt.Sprintf("%s(): msg\n\t%s:%d\n\tContainer size: 1\n\tContainer fields: a: 1; absent: <nil>;\n", fn, err.File, line+1)
+	if err.MultiLinePrettyError() != want {
+		t.Errorf("Want '%s', got '%s'", want, err.MultiLinePrettyError())
+	}
+}
+
+//nolint:paralleltest
+func TestCustomLayout(t *testing.T) {
+	kindLayout := goexer.MustLayout("kind: {{.Message}}", "")
+	goexer.RegisterKind("LayoutTestKind", goexer.KindOpts{Layout: kindLayout})
+
+	optsLayout := goexer.MustLayout("{{.Name}} - {{.Message}}", "{{.Message}} at {{base .File}}\n")
+	err := goexer.New("msg", goexer.ErrorOpts{Layout: optsLayout})
+
+	if err.Error() != "BaseError - msg" {
+		t.Errorf("Want 'BaseError - msg', got '%s'", err.Error())
+	}
+	if fmt.Sprintf("%+v", err) != "msg at layout_test.go\n" {
+		t.Errorf("Want 'msg at layout_test.go', got '%+v'", err)
+	}
+
+	err = goexer.New("msg", goexer.ErrorOpts{Name: "LayoutTestKind"})
+	if fmt.Sprintf("%s", err) != "kind: msg" {
+		t.Errorf("Want 'kind: msg', got '%s'", err)
+	}
+	// Multi line template is not set, default should be used.
+	if err.MultiLinePrettyError() != fmt.Sprintf("%s(): msg\n\t%s:%d\n", err.Function, err.File, err.Line) {
+		t.Errorf("Want default multi line template, got '%s'", err.MultiLinePrettyError())
+	}
+
+	goexer.SetDefaultLayout(goexer.MustLayout("default: {{.Message}}", ""))
+	defer goexer.SetDefaultLayout(nil)
+
+	if goexer.New("msg").Error() != "default: msg" {
+		t.Errorf("Want 'default: msg', got '%s'", goexer.New("msg").Error())
+	}
+
+	// Failed template falls back to the next layout.
+	err = goexer.New("msg", goexer.ErrorOpts{Layout: goexer.MustLayout("{{.Unknown}}", "")})
+	if err.Error() != "default: msg" {
+		t.Errorf("Want 'default: msg', got '%s'", err.Error())
+	}
+}
+
+func TestNewLayoutError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := goexer.NewLayout("{{.Name", ""); err == nil {
+		t.Error("Want error for incorrect template")
+	}
+	if _, err := goexer.NewLayout("", "{{if}}"); err == nil {
+		t.Error("Want error for incorrect template")
+	}
+}
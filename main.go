@@ -0,0 +1,135 @@
+package goexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Process exit codes (sysexits.h). See KindOpts.ExitCode.
+const (
+	ExitOK          = 0
+	ExitFailure     = 1   // Errors without exit code of kind.
+	ExitUsage       = 64  // Command line usage error.
+	ExitDataErr     = 65  // Data format error.
+	ExitNoInput     = 66  // Cannot open input.
+	ExitNoUser      = 67  // Addressee unknown.
+	ExitNoHost      = 68  // Host name unknown.
+	ExitUnavailable = 69  // Service unavailable.
+	ExitSoftware    = 70  // Internal software error.
+	ExitOSErr       = 71  // System error (e.g., can't fork).
+	ExitOSFile      = 72  // Critical OS file missing.
+	ExitCantCreat   = 73  // Can't create (user) output file.
+	ExitIOErr       = 74  // Input/output error.
+	ExitTempFail    = 75  // Temp failure; user is invited to retry.
+	ExitProtocol    = 76  // Remote error in protocol.
+	ExitNoPerm      = 77  // Permission denied.
+	ExitConfig      = 78  // Configuration error.
+	ExitInterrupted = 130 // Canceled, like interrupted by SIGINT.
+)
+
+// MainFormat - how Main() prints error to stderr.
+type MainFormat int8
+
+const (
+	MainFormatOneLine MainFormat = iota // Error(). Default.
+	MainFormatStack                     // StackString(), colored if stderr is a terminal. See FprintStack().
+	MainFormatJSON                      // JSON (see MarshalJSON()).
+	MainFormatNone                      // Do not print, only log.
+)
+
+// Options for Main().
+type MainOpts struct {
+	Format MainFormat
+	Stderr io.Writer      // Writer for error. os.Stderr if nil.
+	Exit   func(code int) // Exit function. os.Exit if nil. Useful for tests.
+}
+
+var DefaultMainOpts = MainOpts{
+	Format: MainFormatOneLine,
+	Stderr: os.Stderr,
+	Exit:   os.Exit,
+}
+
+// ExitCode - return process exit code for err: exit code of the outer error in stack with kind which has it
+// (see KindOpts.ExitCode). Non goexer errors are classified (see RegisterClassifier()).
+// Returns ExitOK for nil and ExitFailure if there is no exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var e *Error
+	if IsGoexerError(err) {
+		e = ToError(err)
+	} else {
+		e = foreignError(2, err)
+	}
+
+	for ; e != nil; e = e.Previous {
+		if kind, ok := LookupKind(e.Name); ok && kind.ExitCode != 0 {
+			return kind.ExitCode
+		}
+	}
+
+	return ExitFailure
+}
+
+// Main - run fn, print returned error to stderr, log it (if logger is set, see SetZLog(), SetBLog())
+// and exit with ExitCode(). Exits with ExitOK if fn returns nil.
+//
+//	func main() {
+//		goexer.Main(run, goexer.MainOpts{Format: goexer.MainFormatStack})
+//	}
+func Main(fn func() error, args ...MainOpts) {
+	if len(args) > 1 {
+		fatal(New("Only one or zero MainOpts could be passed to Main()"), "Only one or zero MainOpts could be passed to Main()")
+	}
+
+	opts := DefaultMainOpts
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	if opts.Stderr == nil {
+		opts.Stderr = os.Stderr
+	}
+	if opts.Exit == nil {
+		opts.Exit = os.Exit
+	}
+
+	err := fn()
+	if err == nil {
+		opts.Exit(ExitOK)
+
+		return
+	}
+
+	var e *Error
+	if IsGoexerError(err) {
+		e = ToError(err)
+	} else {
+		e = foreignError(2, err)
+	}
+
+	switch opts.Format {
+	case MainFormatStack:
+		_, _ = FprintStack(opts.Stderr, e)
+	case MainFormatJSON:
+		_ = json.NewEncoder(opts.Stderr).Encode(e)
+	case MainFormatNone:
+	default:
+		fmt.Fprintln(opts.Stderr, e.Error())
+	}
+
+	// Main exits by itself.
+	if e.Severity == SeverityFatal {
+		e.LogError()
+	} else {
+		e.Log()
+	}
+
+	opts.Exit(ExitCode(e))
+}
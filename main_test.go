@@ -0,0 +1,95 @@
+package goexer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+//nolint:paralleltest
+func TestExitCode(t *testing.T) {
+	goexer.RegisterKind("ExitTestConfig", goexer.KindOpts{ExitCode: goexer.ExitConfig})
+
+	_, openErr := os.Open("/nonexistent/goexer/file")
+
+	test := []struct {
+		Err  error
+		Want int
+	}{
+		{nil, goexer.ExitOK},
+		{goexer.New("failed"), goexer.ExitFailure},
+		{errors.New("plain"), goexer.ExitFailure}, //nolint:goerr113
+		{openErr, goexer.ExitNoInput},
+		{goexer.Wrap(openErr, "can't read"), goexer.ExitNoInput},
+		{goexer.Wrap(context.Canceled, "stopped"), goexer.ExitInterrupted},
+		{goexer.New("bad config", goexer.ErrorOpts{Name: "ExitTestConfig"}), goexer.ExitConfig},
+		{goexer.Wrap(goexer.New("bad config", goexer.ErrorOpts{Name: "ExitTestConfig"}), "start"), goexer.ExitConfig},
+	}
+
+	for _, tt := range test {
+		if got := goexer.ExitCode(tt.Err); got != tt.Want {
+			t.Errorf("%v: want exit code %d, got %d", tt.Err, tt.Want, got)
+		}
+	}
+}
+
+func TestMainRun(t *testing.T) {
+	t.Parallel()
+
+	test := []struct {
+		Format goexer.MainFormat
+		Err    error
+		Code   int
+		Output string
+	}{
+		{goexer.MainFormatOneLine, nil, goexer.ExitOK, ""},
+		{goexer.MainFormatOneLine, goexer.New("failed"), goexer.ExitFailure, "BaseError: "},
+		{goexer.MainFormatStack, goexer.Wrap(context.DeadlineExceeded, "slow"), goexer.ExitTempFail, "main_test.go"},
+		{goexer.MainFormatJSON, goexer.New("failed"), goexer.ExitFailure, `"message":"failed"`},
+		{goexer.MainFormatOneLine, errors.New("plain"), goexer.ExitFailure, "'plain'"}, //nolint:goerr113
+		{goexer.MainFormatNone, goexer.New("failed"), goexer.ExitFailure, ""},
+	}
+
+	for _, tt := range test {
+		buf := &bytes.Buffer{}
+		code := -1
+
+		goexer.Main(func() error { return tt.Err }, goexer.MainOpts{
+			Format: tt.Format,
+			Stderr: buf,
+			Exit:   func(c int) { code = c },
+		})
+
+		if code != tt.Code {
+			t.Errorf("%v: want exit code %d, got %d", tt.Err, tt.Code, code)
+		}
+		if !strings.Contains(buf.String(), tt.Output) || (tt.Output == "" && buf.Len() > 0) {
+			t.Errorf("%v: want output with '%s', got '%s'", tt.Err, tt.Output, buf.String())
+		}
+		if tt.Format == goexer.MainFormatJSON && !json.Valid(buf.Bytes()) {
+			t.Errorf("Want JSON output, got '%s'", buf.String())
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestMainBasicLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	goexer.SetBLog(log.New(buf, "", 0))
+	defer goexer.SetBLog(nil)
+
+	goexer.Main(func() error {
+		return goexer.New("disk is full", goexer.ErrorOpts{Severity: goexer.SeverityCritical})
+	}, goexer.MainOpts{Format: goexer.MainFormatNone, Exit: func(int) {}})
+
+	if !strings.HasPrefix(buf.String(), "CRITICAL ") || !strings.Contains(buf.String(), "disk is full") {
+		t.Errorf("Want critical error in basic log, got '%s'", buf.String())
+	}
+}
@@ -0,0 +1,100 @@
+package goexer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Placeholder in message template, e.g. "{user_id}".
+var placeholderRe = regexp.MustCompile(`\{([A-Za-z0-9_.]+)\}`)
+
+// TemplateParams - return names of placeholders in message template in order of first appearance.
+// E.g. ["user_id"] for "user {user_id} not found".
+func TemplateParams(tmpl string) []string {
+	params := []string{}
+	seen := map[string]bool{}
+
+	for _, m := range placeholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			params = append(params, m[1])
+		}
+	}
+
+	return params
+}
+
+// RenderTemplate - replace placeholders in tmpl with values from params. Placeholders without values are kept.
+func RenderTemplate(tmpl string, params map[string]any) string {
+	return placeholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		v, ok := params[p[1:len(p)-1]]
+		if !ok || v == nil {
+			return p
+		}
+
+		return renderValue(v)
+	})
+}
+
+// Return value of template parameter as string.
+func renderValue(v any) string {
+	return fmt.Sprintf("%v", v)
+}
+
+// Return params of template with positional args. Extra args are ignored.
+func templateArgs(tmpl string, args []any) map[string]any {
+	params := TemplateParams(tmpl)
+	values := make(map[string]any, len(params))
+
+	for i, p := range params {
+		if i < len(args) {
+			values[p] = args[i]
+		}
+	}
+
+	return values
+}
+
+// NewT - create new Error with message template, e.g. NewT("user {user_id} not found", 10).
+// Args are positional values of placeholders, they are stored in container ("user_id": 10).
+// Message is the filled template, raw template is stored in MessageTemplate.
+func NewT(tmpl string, args ...any) *Error {
+	return newT(3, tmpl, DefaultErrorOpts, args)
+}
+
+// NewTOpts - create new Error with message template and options. See NewT().
+func NewTOpts(tmpl string, opts ErrorOpts, args ...any) *Error {
+	return newT(3, tmpl, opts, args)
+}
+
+// newT - common part of NewT() and NewTOpts().
+func newT(depth int, tmpl string, opts ErrorOpts, args []any) *Error {
+	params := templateArgs(tmpl, args)
+
+	err := newError(depth, RenderTemplate(tmpl, params), opts)
+	err.MessageTemplate = tmpl
+	err.addFields(params)
+
+	return err
+}
+
+// WrapT - wrap old error to the new one with message template. See NewT().
+func WrapT(prev error, tmpl string, args ...any) *Error {
+	return wrapT(4, prev, tmpl, DefaultErrorOpts, args)
+}
+
+// WrapTOpts - wrap old error to the new one with message template and options. See NewT(), Wrap().
+func WrapTOpts(prev error, tmpl string, opts ErrorOpts, args ...any) *Error {
+	return wrapT(4, prev, tmpl, opts, args)
+}
+
+// wrapT - common part of WrapT() and WrapTOpts().
+func wrapT(depth int, prev error, tmpl string, opts ErrorOpts, args []any) *Error {
+	params := templateArgs(tmpl, args)
+
+	err := wrap(depth, prev, RenderTemplate(tmpl, params), opts)
+	err.MessageTemplate = tmpl
+	err.addFields(params)
+
+	return err
+}
@@ -0,0 +1,115 @@
+package goexer_test
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestTemplateParams(t *testing.T) {
+	t.Parallel()
+
+	params := goexer.TemplateParams("user {user_id} of {tenant} not found, {user_id} {}")
+	if !reflect.DeepEqual(params, []string{"user_id", "tenant"}) {
+		t.Errorf("Want [user_id tenant], got %v", params)
+	}
+
+	msg := goexer.RenderTemplate("user {user_id} of {tenant} not found", map[string]any{"user_id": 10})
+	if msg != "user 10 of {tenant} not found" {
+		t.Errorf("Want 'user 10 of {tenant} not found', got '%s'", msg)
+	}
+}
+
+func TestNewT(t *testing.T) {
+	t.Parallel()
+
+	_, file, line, _ := runtime.Caller(0)
+	err := goexer.NewT("user {user_id} of {tenant} not found", 10, "acme", "extra")
+
+	if err.File != file || err.Line != uint(line+1) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+1, err.File, err.Line)
+	}
+	if err.Message != "user 10 of acme not found" {
+		t.Errorf("Want message 'user 10 of acme not found', got '%s'", err.Message)
+	}
+	if err.MessageTemplate != "user {user_id} of {tenant} not found" {
+		t.Errorf("Want raw template, got '%s'", err.MessageTemplate)
+	}
+	if err.Get("user_id") != 10 || err.Get("tenant") != "acme" || err.Container.Size() != 2 {
+		t.Errorf("Want params in container, got %v", err.Container.Marshaled())
+	}
+
+	// Missing args.
+	err = goexer.NewT("user {user_id} of {tenant} not found", 10)
+	if err.Message != "user 10 of {tenant} not found" {
+		t.Errorf("Want 'user 10 of {tenant} not found', got '%s'", err.Message)
+	}
+}
+
+func TestWrapT(t *testing.T) {
+	t.Parallel()
+
+	_, file, line, _ := runtime.Caller(0)
+	//nolint:goerr113
+	err := goexer.WrapT(errors.New("no rows"), "can't load order {order_id}", 42)
+
+	if err.File != file || err.Line != uint(line+2) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+2, err.File, err.Line)
+	}
+	if err.Message != "can't load order 42" || err.Get("order_id") != 42 {
+		t.Errorf("Incorrect error: %v", err)
+	}
+	if err.Previous == nil || err.Previous.Message != "no rows" {
+		t.Errorf("Incorrect previous error: %v", err.Previous)
+	}
+}
+
+func TestTemplateOpts(t *testing.T) {
+	t.Parallel()
+
+	_, file, line, _ := runtime.Caller(0)
+	err := goexer.NewTOpts("user {user_id} not found", goexer.ErrorOpts{
+		Name:      goexer.NotFoundErrorName,
+		Severity:  goexer.SeverityInfo,
+		Container: goexer.NewContainer().Set("tenant", "acme"),
+	}, 10)
+
+	if err.File != file || err.Line != uint(line+1) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+1, err.File, err.Line)
+	}
+	if err.Name != goexer.NotFoundErrorName || err.Severity != goexer.SeverityInfo || err.Message != "user 10 not found" {
+		t.Errorf("Options should be applied: %v (%s)", err, err.Severity)
+	}
+	if err.Get("tenant") != "acme" || err.Get("user_id") != 10 {
+		t.Errorf("Want fields of container and params, got %v", err.Container.Marshaled())
+	}
+
+	_, file, line, _ = runtime.Caller(0)
+	//nolint:goerr113
+	err = goexer.WrapTOpts(errors.New("timeout"), "can't load order {order_id}", goexer.ErrorOpts{Name: "OrderError"}, 42)
+
+	if err.File != file || err.Line != uint(line+2) {
+		t.Errorf("Want location %s:%d, got %s:%d", file, line+2, err.File, err.Line)
+	}
+	if err.Name != "OrderError" || err.Message != "can't load order 42" || err.Get("order_id") != 42 {
+		t.Errorf("Options should be applied: %v", err)
+	}
+}
+
+//nolint:paralleltest
+func TestFingerprintMessageTemplate(t *testing.T) {
+	goexer.SetFingerprintOpts(goexer.FingerprintOpts{WithMessage: true})
+	defer goexer.SetFingerprintOpts(goexer.FingerprintOpts{})
+
+	errs := []*goexer.Error{}
+	for _, id := range []int{1, 2} {
+		errs = append(errs, goexer.NewT("user {user_id} not found", id))
+	}
+
+	if errs[0].Fingerprint() != errs[1].Fingerprint() {
+		t.Errorf("Errors with the same template should have the same fingerprint")
+	}
+}
@@ -0,0 +1,75 @@
+package goexer
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// Kinds of OS and syscall errors. NotFound is shared with sql.ErrNoRows.
+const (
+	PermissionDeniedErrorName  = "PermissionDenied"  // fs.ErrPermission, EACCES, EPERM.
+	AlreadyExistsErrorName     = "AlreadyExists"     // fs.ErrExist, EEXIST.
+	TimeoutErrorName           = "Timeout"           // os.ErrDeadlineExceeded, ETIMEDOUT, network timeouts.
+	ConnectionRefusedErrorName = "ConnectionRefused" // ECONNREFUSED.
+)
+
+// Recognize OS and syscall errors. Adds "op", "path" (or "addr" for network errors), "errno" and "errno_name" fields.
+func classifyOS(orig error, err *Error) string {
+	fields := map[string]any{}
+
+	var (
+		pathErr    *fs.PathError
+		linkErr    *os.LinkError
+		syscallErr *os.SyscallError
+		opErr      *net.OpError
+	)
+
+	switch {
+	case errors.As(orig, &pathErr):
+		fields["op"], fields["path"] = pathErr.Op, pathErr.Path
+	case errors.As(orig, &linkErr):
+		fields["op"], fields["path"] = linkErr.Op, linkErr.Old
+	case errors.As(orig, &opErr):
+		fields["op"] = opErr.Op
+		if opErr.Addr != nil {
+			fields["addr"] = opErr.Addr.String()
+		}
+	}
+
+	if _, ok := fields["op"]; !ok && errors.As(orig, &syscallErr) {
+		fields["op"] = syscallErr.Syscall
+	}
+
+	errnoFields(orig, fields)
+
+	err.addFields(fields)
+
+	return osKind(orig)
+}
+
+// Return kind of OS error or empty string.
+func osKind(orig error) string {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(orig, fs.ErrNotExist):
+		return NotFoundErrorName
+	case errors.Is(orig, fs.ErrPermission):
+		return PermissionDeniedErrorName
+	case errors.Is(orig, fs.ErrExist):
+		return AlreadyExistsErrorName
+	case isConnRefused(orig):
+		return ConnectionRefusedErrorName
+	case errors.Is(orig, context.DeadlineExceeded):
+		return "" // Context errors have own kind.
+	case errors.Is(orig, os.ErrDeadlineExceeded), isTimedOut(orig):
+		return TimeoutErrorName
+	case errors.As(orig, &netErr) && netErr.Timeout():
+		return TimeoutErrorName
+	}
+
+	return ""
+}
@@ -0,0 +1,58 @@
+//go:build !plan9
+
+package goexer
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Names of errno values stored in container as "errno_name".
+var errnoNames = map[syscall.Errno]string{
+	syscall.EPERM:        "EPERM",
+	syscall.ENOENT:       "ENOENT",
+	syscall.EINTR:        "EINTR",
+	syscall.EIO:          "EIO",
+	syscall.EBADF:        "EBADF",
+	syscall.EAGAIN:       "EAGAIN",
+	syscall.ENOMEM:       "ENOMEM",
+	syscall.EACCES:       "EACCES",
+	syscall.EBUSY:        "EBUSY",
+	syscall.EEXIST:       "EEXIST",
+	syscall.ENOTDIR:      "ENOTDIR",
+	syscall.EISDIR:       "EISDIR",
+	syscall.EINVAL:       "EINVAL",
+	syscall.EMFILE:       "EMFILE",
+	syscall.ENOSPC:       "ENOSPC",
+	syscall.EROFS:        "EROFS",
+	syscall.EPIPE:        "EPIPE",
+	syscall.ENOTEMPTY:    "ENOTEMPTY",
+	syscall.EADDRINUSE:   "EADDRINUSE",
+	syscall.ENETUNREACH:  "ENETUNREACH",
+	syscall.ECONNABORTED: "ECONNABORTED",
+	syscall.ECONNRESET:   "ECONNRESET",
+	syscall.ETIMEDOUT:    "ETIMEDOUT",
+	syscall.ECONNREFUSED: "ECONNREFUSED",
+	syscall.EHOSTUNREACH: "EHOSTUNREACH",
+}
+
+// Add "errno" and "errno_name" fields for syscall.Errno in chain of orig.
+func errnoFields(orig error, fields map[string]any) {
+	var errno syscall.Errno
+	if errors.As(orig, &errno) && errno != 0 {
+		fields["errno"] = int(errno)
+		if name, ok := errnoNames[errno]; ok {
+			fields["errno_name"] = name
+		}
+	}
+}
+
+// Check if orig is ECONNREFUSED.
+func isConnRefused(orig error) bool {
+	return errors.Is(orig, syscall.ECONNREFUSED)
+}
+
+// Check if orig is ETIMEDOUT.
+func isTimedOut(orig error) bool {
+	return errors.Is(orig, syscall.ETIMEDOUT)
+}
@@ -0,0 +1,14 @@
+package goexer
+
+// Plan 9 has no errno values, errors are recognized by fs and net errors only.
+func errnoFields(error, map[string]any) {}
+
+// Check if orig is ECONNREFUSED. Always false on Plan 9.
+func isConnRefused(error) bool {
+	return false
+}
+
+// Check if orig is ETIMEDOUT. Always false on Plan 9.
+func isTimedOut(error) bool {
+	return false
+}
@@ -0,0 +1,62 @@
+//go:build !plan9
+
+package goexer_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestClassifyOS(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.conf")
+	_, openErr := os.Open(missing)
+
+	dir := t.TempDir()
+	mkdirErr := os.Mkdir(dir, 0o700)
+
+	test := []struct {
+		Err    error
+		Kind   string
+		Fields map[string]any
+	}{
+		{openErr, goexer.NotFoundErrorName, map[string]any{"op": "open", "path": missing, "errno": int(syscall.ENOENT), "errno_name": "ENOENT"}},
+		{mkdirErr, goexer.AlreadyExistsErrorName, map[string]any{"op": "mkdir", "path": dir, "errno_name": "EEXIST"}},
+		{&os.PathError{Op: "open", Path: "/etc/shadow", Err: syscall.EACCES}, goexer.PermissionDeniedErrorName, map[string]any{"op": "open", "path": "/etc/shadow", "errno_name": "EACCES"}},
+		{os.NewSyscallError("connect", syscall.ECONNREFUSED), goexer.ConnectionRefusedErrorName, map[string]any{"op": "connect", "errno_name": "ECONNREFUSED"}},
+		{
+			&net.OpError{Op: "dial", Net: "tcp", Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}, Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			goexer.ConnectionRefusedErrorName, map[string]any{"op": "dial", "addr": "127.0.0.1:1", "errno_name": "ECONNREFUSED"},
+		},
+		{&os.PathError{Op: "read", Path: "/dev/tty", Err: os.ErrDeadlineExceeded}, goexer.TimeoutErrorName, map[string]any{"op": "read", "path": "/dev/tty"}},
+		{syscall.ETIMEDOUT, goexer.TimeoutErrorName, map[string]any{"errno_name": "ETIMEDOUT"}},
+		{context.DeadlineExceeded, goexer.DeadlineExceededErrorName, map[string]any{}},
+		{errors.New("plain"), goexer.BaseErrorName, map[string]any{}}, //nolint:goerr113
+	}
+
+	for _, tt := range test {
+		err := goexer.Wrap(tt.Err, "failed")
+
+		if err.Name != tt.Kind {
+			t.Errorf("%v: want kind %s, got %s", tt.Err, tt.Kind, err.Name)
+		}
+
+		for k, v := range tt.Fields {
+			if err.Get(k) != v {
+				t.Errorf("%v: want %s=%v, got %v", tt.Err, k, v, err.Get(k))
+			}
+		}
+
+		if len(tt.Fields) == 0 && err.Container.Size() != 0 {
+			t.Errorf("%v: want no fields, got %v", tt.Err, err.Container.Marshaled())
+		}
+	}
+}
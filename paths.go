@@ -0,0 +1,185 @@
+package goexer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// PathMode - how file names of errors are rendered.
+type PathMode int8
+
+const (
+	PathDefault PathMode = iota // Base name in one line style, full path in multi line style.
+	PathFull                    // Full path in all styles.
+	PathBase                    // Base name in all styles.
+	PathModule                  // Path relative to the main module, e.g. "pkg/file.go". Import path for other modules, e.g. "github.com/org/dep/file.go".
+)
+
+// Options for rendering of file and function names. See SetPathOpts().
+type PathOpts struct {
+	Mode           PathMode
+	TrimPrefixes   []string // Prefixes removed from full paths, e.g. GOROOT, GOPATH or CI workspace. See GoTrimPrefixes().
+	Module         string   // Main module path for PathModule. Build info is used if empty.
+	ShortFunctions bool     // Package qualified function names, e.g. "pkg.(*T).Method" instead of "github.com/org/repo/pkg.(*T).Method".
+}
+
+var (
+	pathOpts   PathOpts
+	mainModule string // Main module from build info.
+	mainPkg    string // Import path of main package from build info.
+)
+
+func init() {
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		mainModule = bi.Main.Path
+		mainPkg = strings.TrimSuffix(bi.Path, ".test")
+	}
+}
+
+// SetPathOpts - set options for rendering of file and function names.
+func SetPathOpts(opts PathOpts) {
+	pathOpts = opts
+}
+
+// GoTrimPrefixes - return GOROOT and GOPATH prefixes ("$GOROOT/src/", "$GOPATH/pkg/mod/", "$GOPATH/src/") for PathOpts.TrimPrefixes.
+// GOROOT of the build is used if GOROOT environment variable is not set.
+func GoTrimPrefixes() []string {
+	prefixes := []string{}
+
+	goroot := os.Getenv("GOROOT")
+	if goroot == "" {
+		goroot = runtime.GOROOT() //nolint:staticcheck // Frames of standard library have paths of the build GOROOT.
+	}
+
+	if goroot != "" {
+		prefixes = append(prefixes, filepath.ToSlash(filepath.Join(goroot, "src"))+"/")
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+
+	for _, p := range filepath.SplitList(gopath) {
+		prefixes = append(prefixes,
+			filepath.ToSlash(filepath.Join(p, "pkg", "mod"))+"/",
+			filepath.ToSlash(filepath.Join(p, "src"))+"/",
+		)
+	}
+
+	return prefixes
+}
+
+// ShortFile - file name for one line style. See SetPathOpts().
+func (e *Error) ShortFile() string {
+	if pathOpts.Mode == PathDefault {
+		return filepath.Base(e.File)
+	}
+
+	return filePath(e.File, e.Function)
+}
+
+// FilePath - file name for multi line style. See SetPathOpts().
+func (e *Error) FilePath() string {
+	return filePath(e.File, e.Function)
+}
+
+// FunctionName - function name with respect to PathOpts.ShortFunctions.
+func (e *Error) FunctionName() string {
+	return functionName(e.Function)
+}
+
+// Return file name with respect to PathOpts. function - function from this file.
+func filePath(file, function string) string {
+	switch pathOpts.Mode {
+	case PathBase:
+		return filepath.Base(file)
+	case PathModule:
+		return modulePath(file, function)
+	default:
+		return trimPath(file)
+	}
+}
+
+// Return function name with respect to PathOpts.ShortFunctions.
+func functionName(function string) string {
+	if !pathOpts.ShortFunctions {
+		return function
+	}
+
+	return shortFunction(function)
+}
+
+// Remove first matched prefix from PathOpts.TrimPrefixes.
+func trimPath(file string) string {
+	for _, prefix := range pathOpts.TrimPrefixes {
+		if prefix != "" && strings.HasPrefix(file, prefix) {
+			return strings.TrimPrefix(file, prefix)
+		}
+	}
+
+	return file
+}
+
+// Return file path relative to the main module. Package of function is used for detecting of directory.
+func modulePath(file, function string) string {
+	pkg := funcPackage(function)
+	if pkg == "" {
+		return trimPath(file)
+	}
+	if pkg == "main" {
+		pkg = mainPkg
+	}
+	// External test package is placed in the directory of the package.
+	pkg = strings.TrimSuffix(pkg, "_test")
+
+	module := pathOpts.Module
+	if module == "" {
+		module = mainModule
+	}
+
+	base := filepath.Base(file)
+
+	switch {
+	case module != "" && pkg == module:
+		return base
+	case module != "" && strings.HasPrefix(pkg, module+"/"):
+		return strings.TrimPrefix(pkg, module+"/") + "/" + base
+	default:
+		return pkg + "/" + base
+	}
+}
+
+// Return import path of package from full function name, e.g. "github.com/org/repo/pkg" for
+// "github.com/org/repo/pkg.(*T).Method".
+func funcPackage(function string) string {
+	// Type parameters could contain "/" and ".".
+	name := function
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+
+	slash := strings.LastIndex(name, "/")
+
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+
+	return name[:slash+1+dot]
+}
+
+// Return package qualified function name, e.g. "pkg.(*T).Method" for "github.com/org/repo/pkg.(*T).Method".
+func shortFunction(function string) string {
+	name := function
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+
+	return function[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,96 @@
+package goexer_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+//nolint:paralleltest
+func TestPathOpts(t *testing.T) {
+	defer goexer.SetPathOpts(goexer.PathOpts{})
+
+	err := goexer.New("test")
+	dir := filepath.Dir(err.File)
+
+	if err.ShortFile() != "paths_test.go" || err.FilePath() != err.File {
+		t.Errorf("Default mode: want 'paths_test.go' and '%s', got '%s' and '%s'", err.File, err.ShortFile(), err.FilePath())
+	}
+
+	goexer.SetPathOpts(goexer.PathOpts{Mode: goexer.PathFull, TrimPrefixes: []string{"/not/exists/", filepath.Dir(dir) + "/"}})
+	want := filepath.Base(dir) + "/paths_test.go"
+	if err.ShortFile() != want || err.FilePath() != want {
+		t.Errorf("Full mode: want '%s', got '%s' and '%s'", want, err.ShortFile(), err.FilePath())
+	}
+	if !strings.HasPrefix(err.Error(), "BaseError: "+want+":") {
+		t.Errorf("Error() should use trimmed path, got '%s'", err.Error())
+	}
+
+	goexer.SetPathOpts(goexer.PathOpts{Mode: goexer.PathBase})
+	if err.FilePath() != "paths_test.go" {
+		t.Errorf("Base mode: want 'paths_test.go', got '%s'", err.FilePath())
+	}
+
+	goexer.SetPathOpts(goexer.PathOpts{Mode: goexer.PathModule})
+	if err.FilePath() != "paths_test.go" {
+		t.Errorf("Module mode: want 'paths_test.go', got '%s'", err.FilePath())
+	}
+
+	test := []struct {
+		Module   string
+		Function string
+		Want     string
+	}{
+		{"github.com/org/repo", "github.com/org/repo/pkg.(*T).Method", "pkg/file.go"},
+		{"github.com/org/repo", "github.com/org/repo.Func.func1", "file.go"},
+		{"github.com/org/repo", "github.com/org/dep/sub.Func", "github.com/org/dep/sub/file.go"},
+		{"github.com/org/repo", "github.com/org/repo/pkg.Map[...]", "pkg/file.go"},
+		{"github.com/org/repo", "github.com/org/repo/pkg.Map[github.com/org/repo/types.T]", "pkg/file.go"},
+		{"github.com/org/repo", "net/http.(*conn).serve", "net/http/file.go"},
+	}
+
+	for _, tt := range test {
+		goexer.SetPathOpts(goexer.PathOpts{Mode: goexer.PathModule, Module: tt.Module})
+		e := &goexer.Error{File: "/build/src/file.go", Function: tt.Function}
+		if e.FilePath() != tt.Want {
+			t.Errorf("%s: want '%s', got '%s'", tt.Function, tt.Want, e.FilePath())
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestShortFunctions(t *testing.T) {
+	goexer.SetPathOpts(goexer.PathOpts{ShortFunctions: true})
+	defer goexer.SetPathOpts(goexer.PathOpts{})
+
+	test := map[string]string{
+		"github.com/org/repo/pkg.(*T).Method":           "pkg.(*T).Method",
+		"main.main":                                     "main.main",
+		"github.com/org/repo/pkg.Map[github.com/x/y.T]": "pkg.Map[github.com/x/y.T]",
+	}
+
+	for function, want := range test {
+		e := &goexer.Error{Function: function}
+		if e.FunctionName() != want {
+			t.Errorf("Want '%s', got '%s'", want, e.FunctionName())
+		}
+	}
+
+	err := goexer.New("test")
+	if !strings.Contains(err.Error(), " goexer_test.TestShortFunctions(): ") {
+		t.Errorf("Error() should use short function name, got '%s'", err.Error())
+	}
+}
+
+//nolint:paralleltest
+func TestGoTrimPrefixes(t *testing.T) {
+	t.Setenv("GOROOT", "")
+
+	want := filepath.ToSlash(filepath.Join(runtime.GOROOT(), "src")) + "/" //nolint:staticcheck
+	if prefixes := goexer.GoTrimPrefixes(); len(prefixes) == 0 || prefixes[0] != want {
+		t.Errorf("Want GOROOT prefix '%s' without GOROOT variable, got %v", want, prefixes)
+	}
+}
@@ -0,0 +1,124 @@
+package goexer
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem - problem details for HTTP APIs (RFC 7807). Contains only public parts of error.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"` // Failures of validation error. See Validation.
+}
+
+// InvalidParam - failure of one field in Problem.
+type InvalidParam struct {
+	Name   string `json:"name"`   // Path of field, e.g. "items[3].price".
+	Reason string `json:"reason"` // Message for users.
+	Rule   string `json:"rule,omitempty"`
+}
+
+// SetPublic - set message and detail which could be shown to clients. Message is for developers only.
+func (e *Error) SetPublic(msg, detail string) *Error {
+	e.PublicMessage = msg
+	e.PublicDetail = detail
+
+	return e
+}
+
+// Public - return the most relevant public message and detail of err: from the outer error in stack with public message.
+// Returns empty strings if there is no public message or err is not *Error.
+func Public(err error) (string, string) {
+	if err == nil || !IsGoexerError(err) {
+		return "", ""
+	}
+
+	for e := ToError(err); e != nil; e = e.Previous {
+		if e.PublicMessage != "" {
+			return e.PublicMessage, e.PublicDetail
+		}
+	}
+
+	return "", ""
+}
+
+// HTTPStatus - return HTTP status code for err: KindOpts.HTTPStatus of the outer error in stack with registered status.
+// Returns http.StatusInternalServerError if there is no status.
+func HTTPStatus(err error) int {
+	if err == nil || !IsGoexerError(err) {
+		return http.StatusInternalServerError
+	}
+
+	for e := ToError(err); e != nil; e = e.Previous {
+		if kind, ok := LookupKind(e.Name); ok && kind.HTTPStatus != 0 {
+			return kind.HTTPStatus
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Return public message of err or status text if there is no public message.
+func publicMessage(err error, status int) (string, string) {
+	msg, detail := Public(err)
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	return msg, detail
+}
+
+// WriteHTTP - write public message of err as text/plain response with status from HTTPStatus().
+func WriteHTTP(w http.ResponseWriter, err error) {
+	status := HTTPStatus(err)
+	msg, detail := publicMessage(err, status)
+
+	if detail != "" {
+		msg += ": " + detail
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(msg + "\n"))
+}
+
+// NewProblem - create Problem with public parts of err.
+func NewProblem(err error) Problem {
+	status := HTTPStatus(err)
+	title, detail := publicMessage(err, status)
+
+	p := Problem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+
+	if err != nil && IsGoexerError(err) {
+		p.InvalidParams = invalidParams(ToError(err))
+	}
+
+	return p
+}
+
+// WriteProblem - write public parts of err as application/problem+json response. See NewProblem().
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := NewProblem(err)
+
+	data, e := json.Marshal(p)
+	if e != nil {
+		WriteHTTP(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,125 @@
+package goexer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+//nolint:paralleltest
+func TestPublic(t *testing.T) {
+	goexer.RegisterKind("PublicTestNotFound", goexer.KindOpts{HTTPStatus: http.StatusNotFound})
+
+	inner := goexer.New("select * from users where id = 42: no rows", goexer.ErrorOpts{
+		Name:          "PublicTestNotFound",
+		PublicMessage: "User not found",
+		PublicDetail:  "user 42",
+	})
+	err := goexer.Wrap(inner, "can't load user")
+
+	msg, detail := goexer.Public(err)
+	if msg != "User not found" || detail != "user 42" {
+		t.Errorf("Want 'User not found' and 'user 42', got '%s' and '%s'", msg, detail)
+	}
+
+	// Outer public message is more relevant.
+	err.SetPublic("Login failed", "")
+	if msg, detail := goexer.Public(err); msg != "Login failed" || detail != "" {
+		t.Errorf("Want 'Login failed', got '%s' and '%s'", msg, detail)
+	}
+
+	if msg, _ := goexer.Public(goexer.New("internal")); msg != "" {
+		t.Errorf("Want empty public message, got '%s'", msg)
+	}
+	//nolint:goerr113
+	if msg, _ := goexer.Public(errors.New("internal")); msg != "" {
+		t.Errorf("Want empty public message, got '%s'", msg)
+	}
+
+	if goexer.HTTPStatus(err) != http.StatusNotFound {
+		t.Errorf("Want status 404, got %d", goexer.HTTPStatus(err))
+	}
+	if goexer.HTTPStatus(goexer.Wrap(errors.New("timeout"), "call")) != http.StatusInternalServerError {
+		t.Errorf("Want status 500")
+	}
+}
+
+func TestWriteHTTP(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	goexer.WriteHTTP(w, goexer.New("secret internal details"))
+
+	if w.Code != http.StatusInternalServerError || w.Body.String() != "Internal Server Error\n" {
+		t.Errorf("Want 500 'Internal Server Error', got %d '%s'", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	goexer.WriteHTTP(w, goexer.New("secret internal details").SetPublic("Try later", "maintenance"))
+
+	if w.Body.String() != "Try later: maintenance\n" {
+		t.Errorf("Want 'Try later: maintenance', got '%s'", w.Body.String())
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	err := goexer.New("secret internal details", goexer.ErrorOpts{
+		Name:          goexer.DeadlineExceededErrorName,
+		PublicMessage: "Request timeout",
+		PublicDetail:  "upstream is slow",
+	})
+	goexer.WriteProblem(w, err)
+
+	if w.Header().Get("Content-Type") != "application/problem+json" || w.Code != http.StatusGatewayTimeout {
+		t.Errorf("Want problem+json with status 504, got %d '%s'", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	p := goexer.Problem{}
+	if e := json.Unmarshal(w.Body.Bytes(), &p); e != nil {
+		t.Fatal(e)
+	}
+
+	want := goexer.Problem{Type: "about:blank", Title: "Request timeout", Status: http.StatusGatewayTimeout, Detail: "upstream is slow"}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Want %+v, got %+v", want, p)
+	}
+}
+
+//nolint:paralleltest
+func TestRegisterKindMerge(t *testing.T) {
+	goexer.RegisterKind("MergeTestConflict", goexer.KindOpts{HTTPStatus: http.StatusConflict, ExitCode: goexer.ExitDataErr})
+	goexer.RegisterKind("MergeTestConflict", goexer.KindOpts{Hints: []goexer.Hint{{Text: "Reload the page"}}})
+
+	kind, ok := goexer.LookupKind("MergeTestConflict")
+	if !ok || kind.HTTPStatus != http.StatusConflict || kind.ExitCode != goexer.ExitDataErr || len(kind.Hints) != 1 {
+		t.Errorf("Options should be merged, got %+v", kind)
+	}
+
+	goexer.RegisterKind("MergeTestConflict", goexer.KindOpts{HTTPStatus: http.StatusPreconditionFailed})
+	if kind, _ = goexer.LookupKind("MergeTestConflict"); kind.HTTPStatus != http.StatusPreconditionFailed || len(kind.Hints) != 1 {
+		t.Errorf("Non zero option should be replaced, got %+v", kind)
+	}
+}
+
+func TestProblemNil(t *testing.T) {
+	t.Parallel()
+
+	if p := goexer.NewProblem(nil); p.Status != http.StatusInternalServerError || p.InvalidParams != nil {
+		t.Errorf("Want 500 problem for nil error, got %+v", p)
+	}
+
+	w := httptest.NewRecorder()
+	goexer.WriteProblem(w, nil)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Want 500, got %d", w.Code)
+	}
+}
@@ -0,0 +1,174 @@
+package goexer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Options for Retry().
+type RetryOpts struct {
+	Attempts   int                 // Max count of attempts.
+	Delay      time.Duration       // Delay before second attempt.
+	MaxDelay   time.Duration       // Max delay between attempts. 0 - without limit.
+	Multiplier float64             // Delay multiplier for each next attempt.
+	Sleep      func(time.Duration) // Sleep function. Useful for tests.
+}
+
+var DefaultRetryOpts = RetryOpts{
+	Attempts:   3,
+	Delay:      100 * time.Millisecond,
+	MaxDelay:   0,
+	Multiplier: 2,
+	Sleep:      time.Sleep,
+}
+
+// Recognize transient errors: net.Error with Timeout() and some syscall errors (see retryableErrnos).
+func classifyRetryable(orig error, err *Error) string {
+	if isTransient(orig) {
+		err.Retryable = true
+	}
+
+	return ""
+}
+
+// Check if non goexer error is transient.
+func isTransient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	for _, errno := range retryableErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRetryable - check if operation failed with err could be retried.
+// The outer Error in stack which is Retryable or NotRetryable decides, so wrapper could mark transient error as final.
+// For non goexer errors returns true if err is transient.
+// Error wrapped by non goexer error (e.g. by *url.Error of http.Client) is checked as well.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var ge *Error
+	if !IsGoexerError(err) && !errors.As(err, &ge) {
+		return isTransient(err)
+	}
+	if ge == nil {
+		ge = ToError(err)
+	}
+
+	for e := ge; e != nil; e = e.Previous {
+		switch {
+		case e.NotRetryable:
+			return false
+		case e.Retryable:
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRetryAfter - return max RetryAfter from stack of err. Error wrapped by non goexer error is checked as well.
+// Returns 0 for other non goexer errors.
+func GetRetryAfter(err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	var ge *Error
+	if !errors.As(err, &ge) {
+		return 0
+	}
+
+	var after time.Duration
+
+	for e := ge; e != nil; e = e.Previous {
+		if e.RetryAfter > after {
+			after = e.RetryAfter
+		}
+	}
+
+	return after
+}
+
+// Retry - run fn until it succeeds, returns non retryable error (see IsRetryable()) or attempts are over.
+// Each failed attempt is added to the stack of returned error with field "attempt" in container.
+// Delay between attempts grows with Multiplier, but it's not less than RetryAfter of failed attempt.
+func Retry(fn func() error, args ...RetryOpts) error {
+	if len(args) > 1 {
+		fatal(New("Only one or zero RetryOpts could be passed to Retry()"), "Only one or zero RetryOpts could be passed to Retry()")
+	}
+
+	opts := DefaultRetryOpts
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+
+	var last *Error
+
+	delay := opts.Delay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		last = attemptError(err, attempt, last)
+
+		if attempt >= opts.Attempts || !IsRetryable(err) {
+			return last
+		}
+
+		wait := delay
+		if after := GetRetryAfter(err); after > wait {
+			wait = after
+		}
+		opts.Sleep(wait)
+
+		delay = time.Duration(float64(delay) * opts.Multiplier)
+		if opts.MaxDelay > 0 && delay > opts.MaxDelay {
+			delay = opts.MaxDelay
+		}
+	}
+}
+
+// attemptError - create Error for failed attempt of Retry(). prev - Error of previous attempt.
+func attemptError(err error, attempt int, prev *Error) *Error {
+	msg := errorMessage(err)
+	if IsGoexerError(err) {
+		msg = ToError(err).Message
+	}
+
+	e := newError(3, fmt.Sprintf("attempt %d: %s", attempt, msg), ErrorOpts{Container: NewContainer()})
+	e.Set("attempt", attempt)
+	e.Previous = prev
+	e.Original = err
+	e.Retryable = IsRetryable(err)
+	e.NotRetryable = !e.Retryable // Previous attempts should not make failed attempt retryable.
+	e.RetryAfter = GetRetryAfter(err)
+
+	if IsGoexerError(err) {
+		e.Name = ToError(err).Name
+		e.Severity = ToError(err).Severity
+	} else if kind := classify(err, e); kind != "" {
+		e.Name = kind
+		e.applyKind(ErrorOpts{})
+	}
+
+	return e
+}
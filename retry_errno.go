@@ -0,0 +1,15 @@
+//go:build !plan9
+
+package goexer
+
+import "syscall"
+
+// Transient syscall errors.
+var retryableErrnos = []error{
+	syscall.ECONNRESET,
+	syscall.ECONNREFUSED,
+	syscall.ECONNABORTED,
+	syscall.EPIPE,
+	syscall.ETIMEDOUT,
+	syscall.EAGAIN,
+}
@@ -0,0 +1,4 @@
+package goexer
+
+// Transient syscall errors. Plan 9 has no errno values, network timeouts are recognized by net.Error.
+var retryableErrnos = []error{}
@@ -0,0 +1,183 @@
+//go:build !plan9
+
+package goexer_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Tolyar/goexer"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestWrapRetryable(t *testing.T) {
+	t.Parallel()
+
+	var _ net.Error = timeoutError{}
+
+	test := []struct {
+		Err       error
+		Retryable bool
+	}{
+		{timeoutError{}, true},
+		{&net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}, true},
+		{os.NewSyscallError("read", syscall.ECONNRESET), true},
+		{syscall.ECONNREFUSED, true},
+		{syscall.ENOENT, false},
+		//nolint:goerr113
+		{errors.New("test"), false},
+	}
+
+	for _, tt := range test {
+		err := goexer.Wrap(tt.Err, "wrapped")
+		if err.Retryable != tt.Retryable {
+			t.Errorf("Wrap(%v): want Retryable=%v, got %v", tt.Err, tt.Retryable, err.Retryable)
+		}
+		if goexer.IsRetryable(goexer.Wrap(err, "outer")) != tt.Retryable {
+			t.Errorf("IsRetryable(%v): want %v", tt.Err, tt.Retryable)
+		}
+	}
+
+	f := false
+	if goexer.Wrap(timeoutError{}, "explicit", goexer.ErrorOpts{Retryable: &f}).Retryable {
+		t.Errorf("Explicit Retryable should have priority")
+	}
+}
+
+func TestNewRetryable(t *testing.T) {
+	t.Parallel()
+
+	r := true
+	err := goexer.New("busy", goexer.ErrorOpts{Retryable: &r, RetryAfter: time.Second})
+	err = goexer.Wrap(err, "outer")
+
+	if !err.Retryable || !goexer.IsRetryable(err) {
+		t.Errorf("Want retryable error")
+	}
+	if goexer.GetRetryAfter(err) != time.Second {
+		t.Errorf("Want RetryAfter 1s, got %s", goexer.GetRetryAfter(err))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	sleeps := []time.Duration{}
+	opts := goexer.RetryOpts{
+		Attempts:   4,
+		Delay:      10 * time.Millisecond,
+		MaxDelay:   25 * time.Millisecond,
+		Multiplier: 2,
+		Sleep:      func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+
+	calls := 0
+	err := goexer.Retry(func() error {
+		calls++
+
+		return syscall.ECONNRESET
+	}, opts)
+
+	if calls != 4 {
+		t.Errorf("Want 4 calls, got %d", calls)
+	}
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond}
+	if len(sleeps) != len(want) {
+		t.Fatalf("Want sleeps %v, got %v", want, sleeps)
+	}
+	for i := range want {
+		if sleeps[i] != want[i] {
+			t.Errorf("Want sleeps %v, got %v", want, sleeps)
+		}
+	}
+
+	stack := goexer.ToError(err).Stack()
+	if len(stack) != 4 {
+		t.Fatalf("Want 4 errors in stack, got %d", len(stack))
+	}
+	for i, e := range stack {
+		if e.Get("attempt") != i+1 {
+			t.Errorf("Want attempt %d, got %v", i+1, e.Get("attempt"))
+		}
+		if !errors.Is(e.Original, syscall.ECONNRESET) {
+			t.Errorf("Want original ECONNRESET, got %v", e.Original)
+		}
+	}
+}
+
+func TestRetryStop(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	opts := goexer.DefaultRetryOpts
+	opts.Sleep = func(time.Duration) {}
+
+	err := goexer.Retry(func() error {
+		calls++
+		if calls == 1 {
+			return goexer.New("busy", goexer.ErrorOpts{Retryable: new(bool)})
+		}
+
+		return nil
+	}, opts)
+	if err == nil || calls != 1 {
+		t.Errorf("Non retryable error should stop retries, calls: %d, err: %v", calls, err)
+	}
+
+	calls = 0
+	err = goexer.Retry(func() error {
+		calls++
+		if calls < 3 {
+			return timeoutError{}
+		}
+
+		return nil
+	}, opts)
+	if err != nil || calls != 3 {
+		t.Errorf("Want success after 3 calls, calls: %d, err: %v", calls, err)
+	}
+}
+
+func TestWrapNotRetryable(t *testing.T) {
+	t.Parallel()
+
+	r, f := true, false
+	busy := goexer.New("busy", goexer.ErrorOpts{Retryable: &r})
+
+	final := goexer.Wrap(busy, "retry budget exhausted", goexer.ErrorOpts{Retryable: &f})
+	if goexer.IsRetryable(final) {
+		t.Errorf("Explicitly not retryable wrapper should be final")
+	}
+	if goexer.IsRetryable(goexer.Wrap(final, "outer")) {
+		t.Errorf("Wrapper without options should keep final error final")
+	}
+	if !goexer.IsRetryable(goexer.Wrap(final, "retry anyway", goexer.ErrorOpts{Retryable: &r})) {
+		t.Errorf("Outer retryable wrapper should decide")
+	}
+	if goexer.IsRetryable(goexer.Wrap(timeoutError{}, "not idempotent", goexer.ErrorOpts{Retryable: &f})) {
+		t.Errorf("Explicitly not retryable wrapper of transient error should be final")
+	}
+
+	calls := 0
+	opts := goexer.DefaultRetryOpts
+	opts.Sleep = func(time.Duration) {}
+
+	err := goexer.Retry(func() error {
+		calls++
+
+		return final
+	}, opts)
+	if err == nil || calls != 1 {
+		t.Errorf("Final error should stop retries, calls: %d, err: %v", calls, err)
+	}
+}
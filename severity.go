@@ -0,0 +1,87 @@
+package goexer
+
+// Severity of error. Used by Log() for choosing log level.
+type Severity int8
+
+const (
+	SeverityDefault  Severity = iota // Not set. Severity of kind or SeverityError will be used.
+	SeverityDebug                    // Debug information.
+	SeverityInfo                     // Expected error, e.g. NotFound.
+	SeverityWarn                     // Something went wrong, but could be recovered.
+	SeverityError                    // Error.
+	SeverityCritical                 // Error that requires immediate attention.
+	SeverityFatal                    // Program can't continue. Log() exits program.
+)
+
+// Return string representation of Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "default"
+	}
+}
+
+// Log - log error with level chosen by Severity.
+// SeverityCritical is logged with error level and "severity" field, SeverityFatal exits program.
+// Basic logger has no levels: SeverityCritical and SeverityWarn messages get "CRITICAL" and "WARN" prefixes,
+// debug and info messages are skipped.
+func (e *Error) Log(msg ...string) {
+	switch e.Severity {
+	case SeverityDebug:
+		e.LogDebug(msg...)
+	case SeverityInfo:
+		e.LogInfo(msg...)
+	case SeverityWarn:
+		e.LogWarn(msg...)
+	case SeverityCritical:
+		switch {
+		case zLog != nil:
+			if e.needTrace() {
+				e.LogTraceToEvent(zLog.Error())
+			}
+			e.log(zLog.Error().Str("severity", e.Severity.String()), msg...)
+		case bLog != nil:
+			bLog.Print("CRITICAL " + e.basicLogMessage(e.needTrace(), msg...))
+		}
+	case SeverityFatal:
+		e.LogFatal(msg...)
+	default:
+		e.LogError(msg...)
+	}
+}
+
+// Log with Warn log level. Basic logger message gets "WARN" prefix.
+func (e *Error) LogWarn(msg ...string) {
+	switch {
+	case zLog != nil:
+		e.log(zLog.Warn(), msg...)
+	case bLog != nil:
+		bLog.Print("WARN " + e.basicLogMessage(false, msg...))
+	}
+}
+
+// Log with Info log level.
+func (e *Error) LogInfo(msg ...string) {
+	if zLog != nil {
+		e.log(zLog.Info(), msg...)
+	}
+}
+
+// Log with Debug log level.
+func (e *Error) LogDebug(msg ...string) {
+	if zLog != nil {
+		e.log(zLog.Debug(), msg...)
+	}
+}
@@ -0,0 +1,115 @@
+package goexer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/rs/zerolog"
+)
+
+//nolint:paralleltest
+func TestSeverity(t *testing.T) {
+	goexer.RegisterKind("SeverityTestNotFound", goexer.KindOpts{Severity: goexer.SeverityInfo})
+
+	test := []struct {
+		Err  *goexer.Error
+		Want goexer.Severity
+	}{
+		{goexer.New("default"), goexer.SeverityError},
+		{goexer.New("kind", goexer.ErrorOpts{Name: "SeverityTestNotFound"}), goexer.SeverityInfo},
+		{goexer.New("explicit", goexer.ErrorOpts{Name: "SeverityTestNotFound", Severity: goexer.SeverityCritical}), goexer.SeverityCritical},
+		{goexer.Wrap(goexer.New("kind", goexer.ErrorOpts{Name: "SeverityTestNotFound"}), "wrapped"), goexer.SeverityInfo},
+		//nolint:goerr113
+		{goexer.Wrap(errors.New("original"), "wrapped", goexer.ErrorOpts{Severity: goexer.SeverityWarn}), goexer.SeverityWarn},
+	}
+
+	for _, tt := range test {
+		if tt.Err.Severity != tt.Want {
+			t.Errorf("%s: want severity %s, got %s", tt.Err.Message, tt.Want, tt.Err.Severity)
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestLogSeverity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zlog := zerolog.New(buf)
+	goexer.SetZLog(&zlog)
+	defer goexer.SetZLog(nil)
+
+	test := []struct {
+		Severity goexer.Severity
+		Level    string
+	}{
+		{goexer.SeverityDebug, "debug"},
+		{goexer.SeverityInfo, "info"},
+		{goexer.SeverityWarn, "warn"},
+		{goexer.SeverityError, "error"},
+		{goexer.SeverityCritical, "error"},
+	}
+
+	tr := true
+	for _, tt := range test {
+		buf.Reset()
+		err := goexer.New("test", goexer.ErrorOpts{
+			Severity:             tt.Severity,
+			Container:            goexer.NewContainer().Set("id", 10),
+			ShowContainerItems:   []string{"id"},
+			ShowContainerAsZKeys: &tr,
+		})
+		err.Log("msg")
+
+		rec := map[string]any{}
+		if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+			t.Fatalf("Can't parse log record '%s': %v", buf.String(), e)
+		}
+		if rec["level"] != tt.Level {
+			t.Errorf("%s: want level %s, got %v", tt.Severity, tt.Level, rec["level"])
+		}
+		if rec["field_id"] != "10" {
+			t.Errorf("%s: want field_id=10, got %v", tt.Severity, rec["field_id"])
+		}
+		if tt.Severity == goexer.SeverityCritical && rec["severity"] != "critical" {
+			t.Errorf("Want severity=critical, got %v", rec["severity"])
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestLogSeverityBasic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	goexer.SetBLog(log.New(buf, "", 0))
+	defer goexer.SetBLog(nil)
+
+	test := []struct {
+		Severity goexer.Severity
+		Prefix   string
+	}{
+		{goexer.SeverityDebug, ""},
+		{goexer.SeverityInfo, ""},
+		{goexer.SeverityWarn, "WARN "},
+		{goexer.SeverityError, "github.com/Tolyar/goexer_test.TestLogSeverityBasic"},
+		{goexer.SeverityCritical, "CRITICAL "},
+	}
+
+	for _, tt := range test {
+		buf.Reset()
+		goexer.New("test", goexer.ErrorOpts{Severity: tt.Severity}).Log("msg")
+
+		if tt.Prefix == "" {
+			if buf.Len() > 0 {
+				t.Errorf("%s: want no output, got '%s'", tt.Severity, buf.String())
+			}
+
+			continue
+		}
+		if !strings.HasPrefix(buf.String(), tt.Prefix) || !strings.Contains(buf.String(), "msg") {
+			t.Errorf("%s: want output with prefix '%s', got '%s'", tt.Severity, tt.Prefix, buf.String())
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package goexer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+)
+
+// Kinds of database/sql errors. See RegisterSQLDriver().
+const (
+	NotFoundErrorName             = "NotFound"             // sql.ErrNoRows.
+	TxDoneErrorName               = "TxDone"               // sql.ErrTxDone.
+	IntegrityViolationErrorName   = "IntegrityViolation"   // SQLSTATE class 23 without more specific kind.
+	UniqueViolationErrorName      = "UniqueViolation"      // SQLSTATE 23505.
+	ForeignKeyViolationErrorName  = "ForeignKeyViolation"  // SQLSTATE 23503.
+	NotNullViolationErrorName     = "NotNullViolation"     // SQLSTATE 23502.
+	CheckViolationErrorName       = "CheckViolation"       // SQLSTATE 23514.
+	DeadlockErrorName             = "Deadlock"             // SQLSTATE 40P01. Retryable.
+	SerializationFailureErrorName = "SerializationFailure" // SQLSTATE 40001. Retryable.
+)
+
+// SQLErrorInfo - details of driver error returned by SQLDriverClassifier.
+type SQLErrorInfo struct {
+	Kind       string // Kind of error. Detected by SQLState if empty.
+	SQLState   string // SQLSTATE code, e.g. "23505". Stored in container as "sqlstate".
+	Constraint string // Name of violated constraint. Stored in container as "constraint".
+}
+
+// SQLDriverClassifier - extract details from driver error. Return false if err is not an error of the driver.
+type SQLDriverClassifier func(err error) (SQLErrorInfo, bool)
+
+// Driver classifiers, later have higher priority.
+var sqlDrivers = []SQLDriverClassifier{ClassifySQLState, ClassifyMySQL}
+
+// Kinds for SQLSTATE codes.
+var sqlStateKinds = map[string]string{
+	"23505": UniqueViolationErrorName,
+	"23503": ForeignKeyViolationErrorName,
+	"23502": NotNullViolationErrorName,
+	"23514": CheckViolationErrorName,
+	"40P01": DeadlockErrorName,
+	"40001": SerializationFailureErrorName,
+}
+
+// Kinds and SQLSTATE codes for MySQL error numbers.
+var mysqlErrors = map[uint64]SQLErrorInfo{
+	1062: {Kind: UniqueViolationErrorName, SQLState: "23000"},     // ER_DUP_ENTRY.
+	1451: {Kind: ForeignKeyViolationErrorName, SQLState: "23000"}, // ER_ROW_IS_REFERENCED_2.
+	1452: {Kind: ForeignKeyViolationErrorName, SQLState: "23000"}, // ER_NO_REFERENCED_ROW_2.
+	1048: {Kind: NotNullViolationErrorName, SQLState: "23000"},    // ER_BAD_NULL_ERROR.
+	3819: {Kind: CheckViolationErrorName, SQLState: "HY000"},      // ER_CHECK_CONSTRAINT_VIOLATED.
+	1213: {Kind: DeadlockErrorName, SQLState: "40001"},            // ER_LOCK_DEADLOCK.
+}
+
+var (
+	mysqlKeyRe        = regexp.MustCompile(`for key '([^']+)'`)
+	mysqlConstraintRe = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+)
+
+// RegisterSQLDriver - add classifier for driver errors. Classifiers registered later have higher priority.
+// Should be called before errors are created (e.g. in init()).
+func RegisterSQLDriver(c SQLDriverClassifier) {
+	sqlDrivers = append(sqlDrivers, c)
+}
+
+// Recognize database/sql and driver errors.
+func classifySQL(orig error, err *Error) string {
+	switch {
+	case errors.Is(orig, sql.ErrNoRows):
+		return NotFoundErrorName
+	case errors.Is(orig, sql.ErrTxDone):
+		return TxDoneErrorName
+	case errors.Is(orig, driver.ErrBadConn):
+		err.Retryable = true
+
+		return ""
+	}
+
+	for i := len(sqlDrivers) - 1; i >= 0; i-- {
+		info, ok := sqlDrivers[i](orig)
+		if !ok {
+			continue
+		}
+
+		kind := info.Kind
+		if kind == "" {
+			kind = sqlStateKind(info.SQLState)
+		}
+		if kind == DeadlockErrorName || kind == SerializationFailureErrorName {
+			err.Retryable = true
+		}
+
+		err.addFields(map[string]any{"sqlstate": nonEmpty(info.SQLState), "constraint": nonEmpty(info.Constraint)})
+
+		return kind
+	}
+
+	return ""
+}
+
+// Return kind for SQLSTATE code or empty string.
+func sqlStateKind(state string) string {
+	if kind, ok := sqlStateKinds[state]; ok {
+		return kind
+	}
+	if len(state) == 5 && state[:2] == "23" {
+		return IntegrityViolationErrorName
+	}
+
+	return ""
+}
+
+// Return s or nil for empty string. nil fields are not added to container.
+func nonEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// ClassifySQLState - driver classifier for errors with SQLState() method (pgx, lib/pq).
+// Constraint is taken from ConstraintName (pgx) or Constraint (lib/pq) field.
+func ClassifySQLState(err error) (SQLErrorInfo, bool) {
+	var se interface{ SQLState() string }
+	if !errors.As(err, &se) {
+		return SQLErrorInfo{}, false
+	}
+
+	info := SQLErrorInfo{SQLState: se.SQLState()}
+	if f, ok := structField(se, "ConstraintName", "Constraint"); ok && f.Kind() == reflect.String {
+		info.Constraint = f.String()
+	}
+
+	return info, true
+}
+
+// ClassifyMySQL - driver classifier for errors with the shape of *mysql.MySQLError (github.com/go-sql-driver/mysql):
+// Number (uint16), SQLState ([5]byte) and Message (string) fields. Constraint is parsed from the message.
+func ClassifyMySQL(err error) (SQLErrorInfo, bool) {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		number, state, ok := mysqlFields(e)
+		if !ok {
+			continue
+		}
+
+		info := mysqlErrors[number.Uint()]
+		b := make([]byte, 0, 5)
+		for i := 0; i < 5; i++ {
+			b = append(b, byte(state.Index(i).Uint()))
+		}
+		if b[0] != 0 {
+			info.SQLState = string(b)
+		}
+
+		if m := mysqlKeyRe.FindStringSubmatch(e.Error()); m != nil {
+			info.Constraint = m[1]
+		} else if m := mysqlConstraintRe.FindStringSubmatch(e.Error()); m != nil {
+			info.Constraint = m[1]
+		}
+
+		return info, true
+	}
+
+	return SQLErrorInfo{}, false
+}
+
+// Return Number and SQLState fields of MySQL driver error. Returns false if v has not all fields of *mysql.MySQLError.
+func mysqlFields(v any) (reflect.Value, reflect.Value, bool) {
+	number, ok := structField(v, "Number")
+	if !ok || (number.Kind() != reflect.Uint16 && number.Kind() != reflect.Uint32) {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	state, ok := structField(v, "SQLState")
+	if !ok || state.Kind() != reflect.Array || state.Len() != 5 || state.Type().Elem().Kind() != reflect.Uint8 {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	if msg, ok := structField(v, "Message"); !ok || msg.Kind() != reflect.String {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	return number, state, true
+}
+
+// Return first existing field from names of struct (or pointer to struct) v.
+func structField(v any, names ...string) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for _, name := range names {
+		if f := rv.FieldByName(name); f.IsValid() {
+			return f, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,116 @@
+package goexer_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+// Error like *pgconn.PgError.
+type pgError struct {
+	Code           string
+	ConstraintName string
+}
+
+func (e *pgError) Error() string    { return "pg error " + e.Code }
+func (e *pgError) SQLState() string { return e.Code }
+
+// Error like *mysql.MySQLError.
+type mysqlError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+// Unrelated error with Number field.
+type numberedError struct {
+	Number uint16
+}
+
+func (e *numberedError) Error() string { return fmt.Sprintf("line %d", e.Number) }
+
+// Error of custom driver.
+type customError struct{}
+
+func (customError) Error() string { return "custom" }
+
+func TestClassifySQL(t *testing.T) {
+	t.Parallel()
+
+	test := []struct {
+		Err        error
+		Kind       string
+		SQLState   any
+		Constraint any
+		Retryable  bool
+	}{
+		{sql.ErrNoRows, goexer.NotFoundErrorName, nil, nil, false},
+		{fmt.Errorf("scan: %w", sql.ErrNoRows), goexer.NotFoundErrorName, nil, nil, false},
+		{sql.ErrTxDone, goexer.TxDoneErrorName, nil, nil, false},
+		{fmt.Errorf("query: %w", context.Canceled), goexer.CanceledErrorName, nil, nil, false},
+		{driver.ErrBadConn, goexer.BaseErrorName, nil, nil, true},
+		{&pgError{Code: "23505", ConstraintName: "users_email_key"}, goexer.UniqueViolationErrorName, "23505", "users_email_key", false},
+		{fmt.Errorf("insert: %w", &pgError{Code: "23503"}), goexer.ForeignKeyViolationErrorName, "23503", nil, false},
+		{&pgError{Code: "23P01"}, goexer.IntegrityViolationErrorName, "23P01", nil, false},
+		{&pgError{Code: "40P01"}, goexer.DeadlockErrorName, "40P01", nil, true},
+		{&pgError{Code: "40001"}, goexer.SerializationFailureErrorName, "40001", nil, true},
+		{&pgError{Code: "42601"}, goexer.BaseErrorName, "42601", nil, false},
+		{
+			&mysqlError{Number: 1062, SQLState: [5]byte{'2', '3', '0', '0', '0'}, Message: "Duplicate entry 'a' for key 'users.email'"},
+			goexer.UniqueViolationErrorName, "23000", "users.email", false,
+		},
+		{
+			&mysqlError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails " +
+				"(`db`.`orders`, CONSTRAINT `orders_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"},
+			goexer.ForeignKeyViolationErrorName, "23000", "orders_user_fk", false,
+		},
+		{&mysqlError{Number: 1213, Message: "Deadlock found"}, goexer.DeadlockErrorName, "40001", nil, true},
+		{&numberedError{Number: 1062}, goexer.BaseErrorName, nil, nil, false},
+	}
+
+	for _, tt := range test {
+		err := goexer.Wrap(tt.Err, "query")
+
+		if err.Name != tt.Kind || err.Previous.Name != tt.Kind {
+			t.Errorf("%v: want kind %s, got %s", tt.Err, tt.Kind, err.Name)
+		}
+		if err.Retryable != tt.Retryable {
+			t.Errorf("%v: want retryable %v, got %v", tt.Err, tt.Retryable, err.Retryable)
+		}
+		if err.Get("sqlstate") != tt.SQLState || err.Get("constraint") != tt.Constraint {
+			t.Errorf("%v: want sqlstate %v and constraint %v, got %v and %v",
+				tt.Err, tt.SQLState, tt.Constraint, err.Get("sqlstate"), err.Get("constraint"))
+		}
+	}
+
+	if s := goexer.HTTPStatus(goexer.Wrap(sql.ErrNoRows, "select")); s != 404 {
+		t.Errorf("Want 404 for NotFound, got %d", s)
+	}
+}
+
+//nolint:paralleltest
+func TestRegisterSQLDriver(t *testing.T) {
+	goexer.RegisterSQLDriver(func(err error) (goexer.SQLErrorInfo, bool) {
+		if _, ok := err.(customError); ok { //nolint:errorlint
+			return goexer.SQLErrorInfo{Kind: "CustomDriverError", Constraint: "custom_key"}, true
+		}
+
+		return goexer.SQLErrorInfo{}, false
+	})
+
+	err := goexer.Wrap(customError{}, "query")
+	if err.Name != "CustomDriverError" || err.Get("constraint") != "custom_key" || err.Get("sqlstate") != nil {
+		t.Errorf("Want CustomDriverError with constraint, got %s %v", err.Name, err.Container.Marshaled())
+	}
+
+	// Explicit name has priority.
+	if err := goexer.Wrap(sql.ErrNoRows, "select", goexer.ErrorOpts{Name: "UserNotFound"}); err.Name != "UserNotFound" {
+		t.Errorf("Want UserNotFound, got %s", err.Name)
+	}
+}
@@ -0,0 +1,47 @@
+package goexer
+
+// TraceRule - decide if trace (StackString()) should be added to error and fatal messages. See SetTraceRules().
+type TraceRule func(e *Error) bool
+
+var traceRules []TraceRule
+
+// SetTraceRules - set rules for adding trace to error and fatal messages. Trace is added if
+// Error.AddTraceToError is true or any of rules returns true. Call without arguments to remove all rules.
+func SetTraceRules(rules ...TraceRule) {
+	traceRules = rules
+}
+
+// TraceForKinds - rule for adding trace to errors of kinds names.
+func TraceForKinds(names ...string) TraceRule {
+	return func(e *Error) bool {
+		for _, name := range names {
+			if e.Name == name {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// TraceForDepth - rule for adding trace to errors with stack longer than depth.
+func TraceForDepth(depth int) TraceRule {
+	return func(e *Error) bool {
+		return len(e.Stack()) > depth
+	}
+}
+
+// needTrace - check if trace should be added to error and fatal messages.
+func (e *Error) needTrace() bool {
+	if e.AddTraceToError {
+		return true
+	}
+
+	for _, rule := range traceRules {
+		if rule(e) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package goexer_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+	"github.com/rs/zerolog"
+)
+
+//nolint:paralleltest
+func TestLogTraceWithoutLoggers(t *testing.T) {
+	goexer.SetZLog(nil)
+	goexer.SetBLog(nil)
+
+	// Should not panic.
+	goexer.New("test").LogTrace("message")
+}
+
+//nolint:paralleltest
+func TestLogTraceBasicLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	goexer.SetBLog(log.New(buf, "", 0))
+	defer goexer.SetBLog(nil)
+
+	//nolint:goerr113
+	err := goexer.Wrap(errors.New("original"), "current")
+	err.LogTrace("message")
+
+	if !strings.Contains(buf.String(), err.StackString()) || !strings.Contains(buf.String(), "message") {
+		t.Errorf("Want trace in basic logger, got '%s'", buf.String())
+	}
+}
+
+// Return count of log records (lines) after LogError().
+func logErrorRecords(err *goexer.Error) int {
+	buf := &bytes.Buffer{}
+	zlog := zerolog.New(buf)
+	goexer.SetZLog(&zlog)
+	defer goexer.SetZLog(nil)
+
+	err.LogError()
+
+	return strings.Count(buf.String(), "\n")
+}
+
+//nolint:paralleltest
+func TestAddTraceToError(t *testing.T) {
+	tr := true
+	goexer.RegisterKind("TraceTestKind", goexer.KindOpts{AddTraceToError: &tr})
+
+	if n := logErrorRecords(goexer.New("test")); n != 1 {
+		t.Errorf("Want 1 record without trace, got %d", n)
+	}
+	if n := logErrorRecords(goexer.New("test", goexer.ErrorOpts{AddTraceToError: &tr})); n != 2 {
+		t.Errorf("Want 2 records with trace from options, got %d", n)
+	}
+	if n := logErrorRecords(goexer.New("test", goexer.ErrorOpts{Name: "TraceTestKind"})); n != 2 {
+		t.Errorf("Want 2 records with trace from kind, got %d", n)
+	}
+
+	f := false
+	if n := logErrorRecords(goexer.New("test", goexer.ErrorOpts{Name: "TraceTestKind", AddTraceToError: &f})); n != 1 {
+		t.Errorf("Options should have priority over kind, got %d records", n)
+	}
+}
+
+//nolint:paralleltest
+func TestTraceRules(t *testing.T) {
+	goexer.SetTraceRules(goexer.TraceForKinds("X", "Y"), goexer.TraceForDepth(2))
+	defer goexer.SetTraceRules()
+
+	test := []struct {
+		Err     *goexer.Error
+		Records int
+	}{
+		{goexer.New("test"), 1},
+		{goexer.New("test", goexer.ErrorOpts{Name: "X"}), 2},
+		{goexer.New("test", goexer.ErrorOpts{Name: "Y"}), 2},
+		{goexer.Wrap(goexer.New("test"), "depth 2"), 1},
+		{goexer.Wrap(goexer.Wrap(goexer.New("test"), "depth 2"), "depth 3"), 2},
+	}
+
+	for _, tt := range test {
+		if n := logErrorRecords(tt.Err); n != tt.Records {
+			t.Errorf("%s: want %d records, got %d", tt.Err, tt.Records, n)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package goexer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Options for TreeString().
+type TreeOpts struct {
+	ShowOriginal bool // Show original non goexer errors as leaves.
+}
+
+// TreeString - return stack and joined errors as tree. Outer error is the root of the tree.
+// Each node shows container fields introduced by its layer. Location identical to the parent is collapsed.
+func (e *Error) TreeString(args ...TreeOpts) string {
+	if len(args) > 1 {
+		fatal(New("Only one or zero TreeOpts could be passed to TreeString()"), "Only one or zero TreeOpts could be passed to TreeString()")
+	}
+
+	opts := TreeOpts{}
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	b := strings.Builder{}
+	e.writeTree(&b, nil, "", "", opts)
+
+	return b.String()
+}
+
+// writeTree - write node and its children. first - prefix for the node line, rest - prefix for next lines.
+func (e *Error) writeTree(b *strings.Builder, parent *Error, first, rest string, opts TreeOpts) {
+	b.WriteString(first + e.treeLabel(parent) + "\n")
+
+	children := e.treeChildren(opts)
+
+	if fields := e.introducedFields(parent); len(fields) > 0 {
+		pipe := "   "
+		if len(children) > 0 {
+			pipe = "│  "
+		}
+		b.WriteString(rest + pipe + "+ fields: " + strings.Join(fields, ", ") + "\n")
+	}
+
+	for i, child := range children {
+		conn, next := "├─ ", "│  "
+		if i == len(children)-1 {
+			conn, next = "└─ ", "   "
+		}
+
+		if ce, ok := child.(*Error); ok {
+			ce.writeTree(b, e, rest+conn, rest+next, opts)
+		} else {
+			fmt.Fprintf(b, "%s%s%T: %s\n", rest, conn, child, errorMessage(child))
+		}
+	}
+}
+
+// Return text of tree node.
+func (e *Error) treeLabel(parent *Error) string {
+	if parent != nil && parent.Function == e.Function && parent.File == e.File && parent.Line == e.Line {
+		return fmt.Sprintf("%s: %s (same location)", e.Name, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s (%s:%d %s)", e.Name, e.Message, e.ShortFile(), e.Line, e.FunctionName())
+}
+
+// Return children of tree node: joined errors, previous error and original error (if requested).
+func (e *Error) treeChildren(opts TreeOpts) []error {
+	children := make([]error, 0, len(e.Joined)+2)
+
+	for _, j := range e.Joined {
+		children = append(children, j)
+	}
+
+	if e.Previous != nil {
+		children = append(children, e.Previous)
+	}
+
+	if opts.ShowOriginal && e.ownOriginal() {
+		children = append(children, e.Original)
+	}
+
+	return children
+}
+
+// ownOriginal - check if Original is not reachable from previous error.
+// Used for showing original error only once, on the deepest layer which refers to it.
+func (e *Error) ownOriginal() bool {
+	orig := e.Original
+
+	return orig != nil && (e.Previous == nil || (!sameError(orig, e.Previous.Original) && !sameError(orig, e.Previous)))
+}
+
+// Check if a and b are the same error. Errors with uncomparable types are never the same.
+func sameError(a, b error) bool {
+	ta := reflect.TypeOf(a)
+
+	return ta != nil && ta == reflect.TypeOf(b) && ta.Comparable() && a == b
+}
+
+// Return container fields ("key=value") added or changed by this layer compared with previous error.
+// Layers which share container (e.g. Wrap() of non goexer error) are credited to the outer layer.
+func (e *Error) introducedFields(parent *Error) []string {
+	if e.Container == nil || (parent != nil && parent.Container == e.Container) {
+		return nil
+	}
+
+	prev := e.Previous
+	for prev != nil && prev.Container == e.Container {
+		prev = prev.Previous
+	}
+
+	keys := e.Container.Keys()
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		v := fmt.Sprintf("%v", e.Get(k))
+		if prev != nil && prev.Container != nil {
+			if pv, ok := prev.GetE(k); ok && fmt.Sprintf("%v", pv) == v {
+				continue
+			}
+		}
+		fields = append(fields, k+"="+v)
+	}
+
+	return fields
+}
@@ -0,0 +1,78 @@
+package goexer_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+var locRe = regexp.MustCompile(`\([^()]+\.go:\d+ [^()]+\)`)
+
+func TestTreeString(t *testing.T) {
+	t.Parallel()
+
+	//nolint:goerr113
+	disk := errors.New("disk")
+	//nolint:goerr113
+	plain := errors.New("plain")
+
+	read := goexer.Wrap(disk, "read", goexer.ErrorOpts{Container: goexer.NewContainer().Set("path", "/a")})
+	notFound := goexer.New("bad", goexer.ErrorOpts{Name: "NotFound"})
+	err := goexer.Wrap(goexer.Join(read, notFound, plain), "outer")
+	err.Set("req", 1)
+
+	want := strings.Join([]string{
+		"BaseError: outer (loc)",
+		"│  + fields: req=1",
+		"└─ BaseError: 3 errors: read; bad; plain (same location)",
+		"   ├─ BaseError: read (loc)",
+		"   │  │  + fields: path=/a",
+		"   │  └─ BaseError: disk (loc)",
+		"   │     └─ *errors.errorString: disk",
+		"   ├─ NotFound: bad (loc)",
+		"   └─ BaseError: plain (same location)",
+		"      └─ *errors.errorString: plain",
+		"",
+	}, "\n")
+
+	got := locRe.ReplaceAllString(err.TreeString(goexer.TreeOpts{ShowOriginal: true}), "(loc)")
+	if got != want {
+		t.Errorf("Want tree:\n%s\ngot:\n%s", want, got)
+	}
+
+	got = locRe.ReplaceAllString(err.TreeString(), "(loc)")
+	if strings.Contains(got, "*errors.errorString") {
+		t.Errorf("Original errors should not be shown by default:\n%s", got)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	if goexer.Join(nil, nil) != nil {
+		t.Error("Join() of nil errors should return nil")
+	}
+
+	notFound := goexer.New("bad", goexer.ErrorOpts{Name: "NotFound"})
+	err := goexer.Join(nil, notFound, context.Canceled)
+
+	if len(err.Joined) != 2 {
+		t.Fatalf("Want 2 joined errors, got %d", len(err.Joined))
+	}
+	if err.Message != "2 errors: bad; context canceled" {
+		t.Errorf("Want message '2 errors: bad; context canceled', got '%s'", err.Message)
+	}
+	if err.Joined[1].Name != goexer.CanceledErrorName {
+		t.Errorf("Want kind %s for joined context error, got %s", goexer.CanceledErrorName, err.Joined[1].Name)
+	}
+	if !errors.Is(err, goexer.New("", goexer.ErrorOpts{Name: goexer.CanceledErrorName})) {
+		t.Error("errors.Is() should find joined Canceled")
+	}
+	if !errors.Is(goexer.Wrap(err, "outer"), goexer.New("", goexer.ErrorOpts{Name: "NotFound"})) {
+		t.Error("errors.Is() should find joined NotFound")
+	}
+}
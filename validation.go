@@ -0,0 +1,159 @@
+package goexer
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Kinds of validation errors.
+const (
+	ValidationErrorName   = "ValidationError" // Error returned by Validation.Err(). Invalid fields are joined errors.
+	InvalidFieldErrorName = "InvalidField"    // Failure of one field with "field", "rule" and "value" fields.
+)
+
+// Kinds are registered in init(), because layout could not be created during initialization of kinds.
+func init() {
+	// Default one line style with list of invalid fields.
+	layout := MustLayout(DefaultOneLineTemplate+
+		`{{with .Joined}} ({{range $i, $f := .}}{{if $i}}; {{end}}{{paint "field" ($f.Get "field")}}: {{$f.Message}}{{end}}){{end}}`, "")
+
+	RegisterKind(ValidationErrorName, KindOpts{HTTPStatus: http.StatusUnprocessableEntity, ExitCode: ExitDataErr, Layout: layout})
+	RegisterKind(InvalidFieldErrorName, KindOpts{HTTPStatus: http.StatusUnprocessableEntity, ExitCode: ExitDataErr})
+}
+
+// Validation - accumulates failures of fields for reporting them at once. See Err().
+// Field(), Index() return Validation for nested field which shares failures with parent.
+//
+//	v := goexer.NewValidation()
+//	v.Check(req.Name != "", "name", "required", "is required", req.Name)
+//	for i, item := range req.Items {
+//		v.Field("items").Index(i).Check(item.Price > 0, "price", "min", "must be positive", item.Price)
+//	}
+//	return v.Err("invalid order") // nil if there are no failures.
+type Validation struct {
+	path  string
+	state *validationState
+}
+
+// Failures shared by Validation and its nested fields.
+type validationState struct {
+	mu     sync.Mutex
+	fields []*Error
+}
+
+// NewValidation - create empty Validation.
+func NewValidation() *Validation {
+	return &Validation{state: &validationState{}}
+}
+
+// Field - return Validation for nested field, e.g. "address.city".
+func (v *Validation) Field(name string) *Validation {
+	return &Validation{path: v.fieldPath(name), state: v.state}
+}
+
+// Index - return Validation for element of slice, e.g. "items[3]".
+func (v *Validation) Index(i int) *Validation {
+	return &Validation{path: v.path + "[" + strconv.Itoa(i) + "]", state: v.state}
+}
+
+// Path - return path of field, e.g. "items[3].price".
+func (v *Validation) Path() string {
+	return v.path
+}
+
+// Add - add failure of field (relative to v, empty for v itself): rule name, message for users and rejected value.
+// Value is stored in "value" field, so it's rendered in JSON and logs. Pass nil for secrets (passwords, tokens, ...).
+func (v *Validation) Add(field, rule, msg string, value any) *Validation {
+	v.add(field, rule, msg, value)
+
+	return v
+}
+
+// Check - add failure if ok is false. See Add() about secret values.
+func (v *Validation) Check(ok bool, field, rule, msg string, value any) *Validation {
+	if !ok {
+		v.add(field, rule, msg, value)
+	}
+
+	return v
+}
+
+// Valid - check if there are no failures.
+func (v *Validation) Valid() bool {
+	return v.Len() == 0
+}
+
+// Len - return count of failures.
+func (v *Validation) Len() int {
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+
+	return len(v.state.fields)
+}
+
+// Err - return Error of ValidationErrorName kind with failures as joined errors.
+// Returns nil error (not typed nil) if there are no failures, so result could be returned as is.
+// msg is used as public message (see Public()), messages of failures are "reason" of problem invalid-params.
+func (v *Validation) Err(msg string) error {
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+
+	if len(v.state.fields) == 0 {
+		return nil
+	}
+
+	err := newError(2, msg, ErrorOpts{Name: ValidationErrorName, PublicMessage: msg})
+	err.Joined = append([]*Error(nil), v.state.fields...)
+
+	return err
+}
+
+// add - common part of Add() and Check(). Location is the caller of Add() or Check().
+func (v *Validation) add(field, rule, msg string, value any) {
+	e := newError(3, msg, ErrorOpts{Name: InvalidFieldErrorName, PublicMessage: msg})
+	e.addFields(map[string]any{"field": v.fieldPath(field), "rule": rule, "value": value})
+
+	v.state.mu.Lock()
+	v.state.fields = append(v.state.fields, e)
+	v.state.mu.Unlock()
+}
+
+// Return path of nested field.
+func (v *Validation) fieldPath(name string) string {
+	switch {
+	case name == "":
+		return v.path
+	case v.path == "":
+		return name
+	default:
+		return v.path + "." + name
+	}
+}
+
+// Return invalid params from the outer validation error in stack of err. See Problem.
+func invalidParams(err *Error) []InvalidParam {
+	for e := err; e != nil; e = e.Previous {
+		// Wrapping errors inherit the kind, but not joined errors.
+		if e.Name != ValidationErrorName || len(e.Joined) == 0 {
+			continue
+		}
+
+		params := make([]InvalidParam, 0, len(e.Joined))
+		for _, f := range e.Joined {
+			field, _ := f.Get("field").(string)
+			rule, _ := f.Get("rule").(string)
+
+			reason := f.PublicMessage
+			if reason == "" {
+				reason = f.Message
+			}
+
+			params = append(params, InvalidParam{Name: field, Reason: reason, Rule: rule})
+		}
+
+		return params
+	}
+
+	return nil
+}
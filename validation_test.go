@@ -0,0 +1,108 @@
+package goexer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+type orderItem struct {
+	Price int
+}
+
+// Validate order like request handler does.
+func validateOrder(name string, items []orderItem) error {
+	v := goexer.NewValidation()
+	v.Check(name != "", "name", "required", "is required", name)
+
+	for i, item := range items {
+		v.Field("items").Index(i).Check(item.Price > 0, "price", "min", "must be positive", item.Price)
+	}
+
+	return v.Err("invalid order")
+}
+
+func TestValidation(t *testing.T) {
+	t.Parallel()
+
+	// validateOrder() returns error, so valid input should give untyped nil.
+	if err := validateOrder("book", []orderItem{{Price: 1}}); err != nil {
+		t.Fatalf("Want nil, got %v", err)
+	}
+
+	var err *goexer.Error
+	if !errors.As(validateOrder("", []orderItem{{Price: 1}, {Price: 5}, {Price: 0}, {Price: -1}}), &err) || err.Name != goexer.ValidationErrorName || len(err.Joined) != 3 {
+		t.Fatalf("Want ValidationError with 3 fields, got %v", err)
+	}
+
+	want := []struct {
+		Field string
+		Value int
+	}{{"items[2].price", 0}, {"items[3].price", -1}}
+
+	if f := err.Joined[0]; f.Get("field") != "name" || f.Get("rule") != "required" || f.Get("value") != "" {
+		t.Errorf("Unexpected fields %v", f.Container.Marshaled())
+	}
+
+	for i, w := range want {
+		f := err.Joined[i+1]
+		if f.Name != goexer.InvalidFieldErrorName || f.Get("field") != w.Field || f.Get("rule") != "min" || f.Get("value") != w.Value {
+			t.Errorf("Want %s=%d, got %v", w.Field, w.Value, f.Container.Marshaled())
+		}
+		if !strings.HasSuffix(f.Function, "validateOrder") {
+			t.Errorf("Want location in validateOrder, got %s", f.Function)
+		}
+	}
+
+	s := regexp.MustCompile(`validation_test.go:\d+`).ReplaceAllString(err.OneLinePrettyError(), "validation_test.go:N")
+	ws := "ValidationError: validation_test.go:N github.com/Tolyar/goexer_test.validateOrder(): 'invalid order' " +
+		"(name: is required; items[2].price: must be positive; items[3].price: must be positive)"
+	if s != ws {
+		t.Errorf("Want '%s', got '%s'", ws, s)
+	}
+
+	j, _ := json.Marshal(err)
+	if !strings.Contains(string(j), `"field":"items[3].price"`) {
+		t.Errorf("Want fields in JSON, got %s", j)
+	}
+}
+
+func TestValidationProblem(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	goexer.WriteProblem(w, goexer.Wrap(validateOrder("", []orderItem{{Price: 0}}), "can't create order"))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Want 422, got %d", w.Code)
+	}
+
+	p := goexer.Problem{}
+	if e := json.Unmarshal(w.Body.Bytes(), &p); e != nil {
+		t.Fatal(e)
+	}
+
+	want := goexer.Problem{
+		Type:   "about:blank",
+		Title:  "invalid order",
+		Status: http.StatusUnprocessableEntity,
+		InvalidParams: []goexer.InvalidParam{
+			{Name: "name", Reason: "is required", Rule: "required"},
+			{Name: "items[0].price", Reason: "must be positive", Rule: "min"},
+		},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Want %+v, got %+v", want, p)
+	}
+
+	if !strings.Contains(w.Body.String(), `"invalid-params":[`) {
+		t.Errorf("Want invalid-params, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,200 @@
+package goexer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TooManyWarningsErrorName - kind of error returned by Warnings.Promote().
+const TooManyWarningsErrorName = "TooManyWarnings"
+
+// Warning - caveat of successful operation. Has location, kind and container like Error, but is not an error.
+type Warning struct {
+	Message   string
+	Name      string // Kind of warning. BaseErrorName by default.
+	Function  string // Function where warning was added.
+	File      string
+	Line      uint
+	Container *Container
+	Original  error // Error recorded by Warnings.AddErr().
+}
+
+// String - warning in one line style (see OneLinePrettyError()).
+func (w Warning) String() string {
+	return w.ToError().OneLinePrettyError()
+}
+
+// Get - return value of container field.
+func (w Warning) Get(key string) any {
+	return w.Container.Get(key)
+}
+
+// ToError - convert warning to Error with SeverityWarn. Error has own copy of container.
+func (w Warning) ToError() *Error {
+	c := w.Container
+	if c != nil {
+		c = c.Clone()
+	}
+
+	return &Error{
+		Message:   w.Message,
+		Name:      w.Name,
+		Function:  w.Function,
+		File:      w.File,
+		Line:      w.Line,
+		Container: c,
+		Original:  w.Original,
+		Severity:  SeverityWarn,
+	}
+}
+
+// Log - log warning with Warn log level. Basic logger gets "WARN" prefix.
+func (w Warning) Log(msg ...string) {
+	e := w.ToError()
+
+	switch {
+	case zLog != nil:
+		e.log(zLog.Warn(), msg...)
+	case bLog != nil:
+		bLog.Print(strings.TrimSpace("WARN " + e.OneLinePrettyError() + " " + strings.Join(msg, " ")))
+	}
+}
+
+// Warnings - accumulates warnings of operation. Zero value is ready to use, methods are safe for concurrent use.
+type Warnings struct {
+	mu   sync.Mutex
+	list []Warning
+}
+
+// Add - add warning with location of the caller. ErrorOpts.Name and ErrorOpts.Container are used.
+func (ws *Warnings) Add(msg string, args ...ErrorOpts) {
+	if len(args) > 1 {
+		fatal(New("Only one or zero ErrorOpts could be passed to Add()"), "Only one or zero ErrorOpts could be passed to Add()")
+	}
+
+	opts := DefaultErrorOpts
+
+	if len(args) == 1 {
+		opts = args[0]
+	}
+
+	ws.add(newError(2+opts.Depth, msg, opts))
+}
+
+// Addf - add warning with formatted message.
+func (ws *Warnings) Addf(format string, args ...any) {
+	ws.add(newError(2, fmt.Sprintf(format, args...), DefaultErrorOpts))
+}
+
+// AddErr - record non fatal error as warning with location of the caller. Kind and container of goexer errors are kept,
+// other errors are classified (see RegisterClassifier()). nil errors are skipped.
+func (ws *Warnings) AddErr(err error) {
+	if err == nil {
+		return
+	}
+
+	e := newError(2, errorMessage(err), DefaultErrorOpts)
+	e.Original = err
+
+	if IsGoexerError(err) {
+		ge := ToError(err)
+		e.Message, e.Name = ge.Message, ge.Name
+		if ge.Container != nil {
+			e.Container = ge.Container.Clone()
+		}
+	} else if kind := classify(err, e); kind != "" {
+		e.Name = kind
+	}
+
+	ws.add(e)
+}
+
+// Record Error as warning.
+func (ws *Warnings) add(e *Error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.list = append(ws.list, Warning{
+		Message:   e.Message,
+		Name:      e.Name,
+		Function:  e.Function,
+		File:      e.File,
+		Line:      e.Line,
+		Container: e.Container,
+		Original:  e.Original,
+	})
+}
+
+// List - return copy of warnings in order of adding.
+func (ws *Warnings) List() []Warning {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	return append([]Warning(nil), ws.list...)
+}
+
+// Len - return count of warnings.
+func (ws *Warnings) Len() int {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	return len(ws.list)
+}
+
+// Merge - add warnings from other, e.g. from result of nested operation.
+func (ws *Warnings) Merge(other *Warnings) {
+	if other == nil || other == ws {
+		return
+	}
+
+	list := other.List()
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.list = append(ws.list, list...)
+}
+
+// Log - log all warnings with Warn log level. See Warning.Log().
+func (ws *Warnings) Log(msg ...string) {
+	for _, w := range ws.List() {
+		w.Log(msg...)
+	}
+}
+
+// Promote - return Error of TooManyWarningsErrorName kind with warnings as joined errors
+// if count of warnings exceeds threshold. Returns nil error (not typed nil) otherwise.
+func (ws *Warnings) Promote(threshold int) error {
+	list := ws.List()
+	if len(list) <= threshold {
+		return nil
+	}
+
+	err := newError(2, fmt.Sprintf("%d warnings (threshold %d)", len(list), threshold), ErrorOpts{Name: TooManyWarningsErrorName})
+	for _, w := range list {
+		err.Joined = append(err.Joined, w.ToError())
+	}
+
+	return err
+}
+
+// Result - value of successful operation with its warnings.
+type Result[T any] struct {
+	Value    T
+	Warnings *Warnings
+}
+
+// NewResult - create Result. nil warnings are replaced with empty Warnings.
+func NewResult[T any](value T, warnings *Warnings) Result[T] {
+	if warnings == nil {
+		warnings = &Warnings{}
+	}
+
+	return Result[T]{Value: value, Warnings: warnings}
+}
+
+// HasWarnings - check if operation has warnings.
+func (r Result[T]) HasWarnings() bool {
+	return r.Warnings != nil && r.Warnings.Len() > 0
+}
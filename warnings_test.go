@@ -0,0 +1,107 @@
+package goexer_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Tolyar/goexer"
+)
+
+func TestWarnings(t *testing.T) {
+	t.Parallel()
+
+	ws := &goexer.Warnings{}
+	ws.Add("deprecated column", goexer.ErrorOpts{Name: "Deprecated", Container: goexer.NewContainer().Set("column", "old_id")})
+	ws.Addf("skipped %d rows", 3)
+	ws.AddErr(goexer.New("not cached", goexer.ErrorOpts{Name: goexer.NotFoundErrorName}))
+	ws.AddErr(nil)
+
+	if ws.Len() != 3 {
+		t.Fatalf("want 3 warnings, got %d", ws.Len())
+	}
+
+	list := ws.List()
+	if list[0].Name != "Deprecated" || list[0].Get("column") != "old_id" {
+		t.Errorf("unexpected first warning: %+v", list[0])
+	}
+	if list[1].Message != "skipped 3 rows" || list[1].Name != goexer.BaseErrorName {
+		t.Errorf("unexpected second warning: %+v", list[1])
+	}
+	if list[2].Name != goexer.NotFoundErrorName || list[2].Original == nil {
+		t.Errorf("unexpected third warning: %+v", list[2])
+	}
+	for _, w := range list {
+		if !strings.HasSuffix(w.File, "warnings_test.go") || !strings.HasSuffix(w.Function, "TestWarnings") {
+			t.Errorf("want location of the caller, got %s %s", w.Function, w.File)
+		}
+	}
+
+	if err := ws.Promote(3); err != nil {
+		t.Errorf("want nil for count <= threshold, got %#v", err)
+	}
+
+	var err *goexer.Error
+	if !errors.As(ws.Promote(2), &err) || err.Name != goexer.TooManyWarningsErrorName {
+		t.Fatalf("want TooManyWarnings error, got %v", err)
+	}
+	if len(err.Joined) != 3 || err.Joined[0].Severity != goexer.SeverityWarn {
+		t.Errorf("want 3 joined warnings, got %+v", err.Joined)
+	}
+}
+
+func TestWarningsContainer(t *testing.T) {
+	t.Parallel()
+
+	src := goexer.New("not cached", goexer.ErrorOpts{Container: goexer.NewContainer().Set("key", "user:1")})
+
+	ws := &goexer.Warnings{}
+	ws.AddErr(src)
+
+	err := goexer.ToError(ws.Promote(0))
+	err.Joined[0].Set("key", "changed")
+	err.Joined[0].Set("extra", 1)
+
+	if src.Get("key") != "user:1" || src.Container.Size() != 1 {
+		t.Errorf("Source error should not be changed, got %v", src.Container.Marshaled())
+	}
+	if w := ws.List()[0]; w.Get("key") != "user:1" || w.Container.Size() != 1 {
+		t.Errorf("Warning should not be changed, got %v", w.Container.Marshaled())
+	}
+}
+
+func TestResult(t *testing.T) {
+	t.Parallel()
+
+	res := goexer.NewResult(42, nil)
+	if res.Value != 42 || res.HasWarnings() {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	inner := &goexer.Warnings{}
+	inner.Add("fallback used")
+	res.Warnings.Merge(inner)
+
+	if !res.HasWarnings() {
+		t.Error("want warnings after merge")
+	}
+}
+
+//nolint:paralleltest
+func TestWarningsLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	goexer.SetBLog(log.New(&buf, "", 0))
+	defer goexer.SetBLog(nil)
+
+	ws := &goexer.Warnings{}
+	ws.Add("slow query")
+	ws.Log("import")
+
+	if out := buf.String(); !strings.HasPrefix(out, "WARN ") || !strings.Contains(out, "slow query") ||
+		!strings.Contains(out, "import") {
+		t.Errorf("unexpected log output: %q", out)
+	}
+}